@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -16,7 +17,7 @@ import (
 	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
 	"github.com/recolude/swagger-unity-codegen/unitygen/model"
 	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
-	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	unitypath "github.com/recolude/swagger-unity-codegen/unitygen/path"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v2"
 )
@@ -109,6 +110,22 @@ func buildReferenceMapping(defs []model.Definition) map[string][]string {
 	return refMapping
 }
 
+// elementType strips away any array brackets, so arrays of definitions can
+// be traced back to the definition itself (ie: Cat[][] => Cat)
+func elementType(variableType string) string {
+	return strings.TrimRight(variableType, "[]")
+}
+
+func keepDefinition(thingsToKeep map[string]bool, referenceMapping map[string][]string, variableType string) {
+	if _, ok := thingsToKeep[variableType]; !ok {
+		return
+	}
+	thingsToKeep[variableType] = true
+	for _, reference := range referenceMapping[variableType] {
+		thingsToKeep[reference] = true
+	}
+}
+
 func filterSpecForUnusedDefinitions(spec unitygen.Spec) unitygen.Spec {
 	thingsToKeep := make(map[string]bool)
 	for _, def := range spec.Definitions {
@@ -121,24 +138,13 @@ func filterSpecForUnusedDefinitions(spec unitygen.Spec) unitygen.Spec {
 		for _, path := range service.Paths() {
 			for _, param := range path.Parameters() {
 				if param.Schema() != nil {
-					if _, ok := thingsToKeep[param.Schema().ToVariableType()]; ok {
-						thingsToKeep[param.Schema().ToVariableType()] = true
-
-						for _, reference := range referenceMapping[param.Schema().ToVariableType()] {
-							thingsToKeep[reference] = true
-						}
-					}
+					keepDefinition(thingsToKeep, referenceMapping, elementType(param.Schema().ToVariableType()))
 				}
 			}
 
 			for _, resp := range path.Responses() {
 				if resp != nil {
-					if _, ok := thingsToKeep[resp.VariableType()]; ok {
-						thingsToKeep[resp.VariableType()] = true
-						for _, reference := range referenceMapping[resp.VariableType()] {
-							thingsToKeep[reference] = true
-						}
-					}
+					keepDefinition(thingsToKeep, referenceMapping, elementType(resp.VariableType()))
 				}
 			}
 		}
@@ -179,9 +185,18 @@ func writeDefinitions(out io.Writer, defs []model.Definition) {
 	}
 }
 
-func writeServices(out io.Writer, services []unitygen.Service, authDefinitions []security.Auth) {
-	for _, def := range services {
-		fmt.Fprintf(out, "%s\n\n", def.ToCSharp(authDefinitions, "Config"))
+func writeServices(out io.Writer, spec unitygen.Spec, configName string, includeScriptableObject bool) {
+	for _, def := range spec.Services {
+		fmt.Fprintf(out, "%s\n\n", def.ToCSharpWithOptions(spec.AuthDefinitions, "Config", spec.ServiceOptions))
+	}
+	fmt.Fprintf(out, "%s\n\n", spec.Client(configName, includeScriptableObject))
+}
+
+func writeCallers(out io.Writer, spec unitygen.Spec, configName string) {
+	for _, service := range spec.Services {
+		for _, p := range service.Paths() {
+			fmt.Fprintf(out, "%s\n\n", service.Caller(p, configName))
+		}
 	}
 }
 
@@ -215,9 +230,15 @@ func allOutAtOnce(c *cli.Context, spec unitygen.Spec) error {
 
 	fmt.Fprintf(c.App.Writer, "%s\n\n", "#region Services")
 	fmt.Fprintf(c.App.Writer, "%s\n\n", spec.ServiceConfig(c.String("config-name"), c.String("config-menu"), c.Bool("scriptable-object-config")))
-	writeServices(c.App.Writer, spec.Services, spec.AuthDefinitions)
+	writeServices(c.App.Writer, spec, c.String("config-name"), c.Bool("scriptable-object-config"))
 	fmt.Fprint(c.App.Writer, "#endregion\n\n")
 
+	if spec.ServiceOptions.Callers {
+		fmt.Fprint(c.App.Writer, "#region Callers\n\n")
+		writeCallers(c.App.Writer, spec, c.String("config-name"))
+		fmt.Fprint(c.App.Writer, "#endregion\n\n")
+	}
+
 	closeNamespace(c.App.Writer, namespace)
 
 	return nil
@@ -248,7 +269,7 @@ func toDir(fs afero.Fs, c *cli.Context, location string, spec unitygen.Spec) err
 	fileCommentHeader(servicesFile)
 	fileImports(servicesFile)
 	openNamespace(servicesFile, namespace)
-	writeServices(servicesFile, spec.Services, spec.AuthDefinitions)
+	writeServices(servicesFile, spec, c.String("config-name"), c.Bool("scriptable-object-config"))
 	closeNamespace(servicesFile, namespace)
 
 	configFile, err := fs.Create(path.Join(location, fmt.Sprintf("%s.cs", convention.TitleCase(c.String("config-name")))))
@@ -261,6 +282,60 @@ func toDir(fs afero.Fs, c *cli.Context, location string, spec unitygen.Spec) err
 	fmt.Fprintf(configFile, "%s\n\n", spec.ServiceConfig(c.String("config-name"), c.String("config-menu"), c.Bool("scriptable-object-config")))
 	closeNamespace(configFile, namespace)
 
+	if spec.ServiceOptions.Callers {
+		// Unity only lets a MonoBehaviour be added to a GameObject when it's in
+		// a file named after it
+		callersLocation := path.Join(location, "Callers")
+		err = fs.MkdirAll(callersLocation, os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("error creating callers folder: %w", err)
+		}
+
+		for _, service := range spec.Services {
+			for _, p := range service.Paths() {
+				callerFile, err := fs.Create(path.Join(callersLocation, fmt.Sprintf("%s.cs", p.CallerName())))
+				if err != nil {
+					return fmt.Errorf("error creating caller file: %w", err)
+				}
+				fileCommentHeader(callerFile)
+				fileImports(callerFile)
+				openNamespace(callerFile, namespace)
+				fmt.Fprintf(callerFile, "%s\n\n", service.Caller(p, c.String("config-name")))
+				closeNamespace(callerFile, namespace)
+			}
+		}
+	}
+
+	if spec.ServiceOptions.VisualScripting {
+		unitsFile, err := fs.Create(path.Join(location, "VisualScriptingUnits.cs"))
+		if err != nil {
+			return fmt.Errorf("error creating visual scripting units file: %w", err)
+		}
+		fileCommentHeader(unitsFile)
+		fileImports(unitsFile)
+		openNamespace(unitsFile, namespace)
+		fmt.Fprintf(unitsFile, "%s\n\n", spec.VisualScriptingUnits(c.String("config-name")))
+		closeNamespace(unitsFile, namespace)
+
+		// The type options use editor only assemblies, which Unity only
+		// references from scripts in an Editor folder
+		editorLocation := path.Join(location, "Editor")
+		err = fs.MkdirAll(editorLocation, os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("error creating editor folder: %w", err)
+		}
+
+		typeOptionsFile, err := fs.Create(path.Join(editorLocation, "VisualScriptingTypeOptions.cs"))
+		if err != nil {
+			return fmt.Errorf("error creating visual scripting type options file: %w", err)
+		}
+		fileCommentHeader(typeOptionsFile)
+		fileImports(typeOptionsFile)
+		openNamespace(typeOptionsFile, namespace)
+		fmt.Fprintf(typeOptionsFile, "%s\n\n", spec.VisualScriptingTypeOptions(c.String("config-name")))
+		closeNamespace(typeOptionsFile, namespace)
+	}
+
 	return nil
 }
 
@@ -311,11 +386,53 @@ func buildApp(fs afero.Fs, out io.Writer, errOut io.Writer) *cli.App {
 						Usage: "Whether or not to include definitions that where never used in the different services",
 						Value: false,
 					},
+					&cli.BoolFlag{
+						Name:  "hoist-array-objects",
+						Usage: "Whether or not classes for objects embedded inside of arrays are written out as top level definitions instead of nested inside the class that uses them",
+						Value: false,
+					},
 					&cli.BoolFlag{
 						Name:  "scriptable-object-config",
 						Usage: "Whether or not to generate a scriptable object that contains all server values different services will use.",
 						Value: true,
 					},
+					&cli.StringFlag{
+						Name:        "secret-storage",
+						Usage:       "Where the scriptable object config keeps secrets like passwords and API keys. One of \"asset\", \"prefs\" (EditorPrefs/PlayerPrefs), \"environment\" (command line args or environment variables), or \"streaming-assets\" (a git-ignored JSON file)",
+						Value:       string(unitygen.AssetSecretStorage),
+						DefaultText: string(unitygen.AssetSecretStorage),
+					},
+					&cli.StringFlag{
+						Name:        "async",
+						Usage:       "Also generate an awaitable version of every service function. One of \"none\", \"task\" (System.Threading.Tasks), \"unitask\" (Cysharp UniTask), or \"awaitable\" (Unity 2023's Awaitable). Awaited requests only wait on yielded IEnumerators and AsyncOperations, anything else (WaitForSeconds, Coroutine) lasts a single frame",
+						Value:       "none",
+						DefaultText: "none",
+					},
+					&cli.BoolFlag{
+						Name:  "callbacks",
+						Usage: "Also generate a version of every service function that takes onSuccess/onError callbacks and runs the request itself",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "fluent",
+						Usage: "Also generate a fluent builder for every request",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "fakes",
+						Usage: "Also generate an in-memory fake of every service for testing without a network",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "callers",
+						Usage: "Also generate a MonoBehaviour for every request that can be set up and called from the Inspector. Requires the scriptable object config",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "visual-scripting",
+						Usage: "Also generate a Visual Scripting unit for every request, along with an editor script registering the generated types. Requires the scriptable object config and --out",
+						Value: false,
+					},
 					&cli.StringSliceFlag{
 						Name:  "tags",
 						Usage: "Specify tags that a route must have to be included in the export. Specifying no tags means include all routes",
@@ -365,10 +482,34 @@ func buildApp(fs afero.Fs, out io.Writer, errOut io.Writer) *cli.App {
 						return fmt.Errorf("unrecognized swagger file format '%s', please provide either json or yml", extension)
 					}
 
-					spec, err := unitygen.NewParser().ParseJSON(jsonStream)
+					parser := unitygen.NewParser()
+					parser.SetHoistArrayObjects(c.Bool("hoist-array-objects"))
+					spec, err := parser.ParseJSON(jsonStream)
 					if err != nil {
 						return fmt.Errorf("error reading from swagger file: %w", err)
 					}
+					spec.SecretStorage, err = unitygen.ParseSecretStorage(c.String("secret-storage"))
+					if err != nil {
+						return err
+					}
+					spec.ServiceOptions.Async, err = unitypath.ParseAsyncFlavor(c.String("async"))
+					if err != nil {
+						return err
+					}
+					spec.ServiceOptions.Callbacks = c.Bool("callbacks")
+					spec.ServiceOptions.Fluent = c.Bool("fluent")
+					spec.ServiceOptions.Fakes = c.Bool("fakes")
+					spec.ServiceOptions.Callers = c.Bool("callers")
+					if spec.ServiceOptions.Callers && !c.Bool("scriptable-object-config") {
+						return errors.New("callers reference the config from the Inspector, so they can't be generated without the scriptable object config")
+					}
+					spec.ServiceOptions.VisualScripting = c.Bool("visual-scripting")
+					if spec.ServiceOptions.VisualScripting && !c.Bool("scriptable-object-config") {
+						return errors.New("visual scripting units take the config as an asset, so they can't be generated without the scriptable object config")
+					}
+					if spec.ServiceOptions.VisualScripting && !c.IsSet("out") {
+						return errors.New("visual scripting units have to be written to a directory with --out, since their type options go in an Editor folder")
+					}
 					spec = filterSpecForTags(spec, c.StringSlice("tags"))
 					if !c.Bool("include-unused") {
 						spec = filterSpecForUnusedDefinitions(spec)
@@ -123,6 +123,7 @@ func TestFilterUnusedDefinitions(t *testing.T) {
 								property.NewDefinitionReference("a", modelX),
 							),
 						},
+						path.PathOptions{},
 					),
 				},
 			),
@@ -146,6 +147,7 @@ func TestFilterUnusedDefinitions(t *testing.T) {
 								property.NewDefinitionReference("a", modelY),
 							),
 						},
+						path.PathOptions{},
 					),
 				},
 			),
@@ -198,15 +200,36 @@ public interface Config {
 	// The base URL to which the endpoint paths are appended
 	string BasePath { get; }
 
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	int Timeout { get; }
+
+	// How requests that fail are retried
+	RetryPolicy RetryPolicy { get; }
+
+	// Interceptors every request and response is run through
+	RequestInterceptors Interceptors { get; }
+
 }
 
-public interface IWebRequest {
+public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
+
+	void Abort();
 }
 
+`+path.ApiResponseClasses+`
+
+`+path.RetryPolicyClass+`
+
+`+path.InterceptorClasses+`
+
 #if UNITY_EDITOR
 [UnityEditor.CustomEditor(typeof(ServiceConfig))]
 public class ServiceConfigEditor : UnityEditor.Editor
@@ -229,6 +252,19 @@ public class ServiceConfigEditor : UnityEditor.Editor
 			UnityEditor.EditorUtility.SetDirty(target);
 		}
 
+		UnityEditor.EditorGUILayout.Space();
+		UnityEditor.EditorGUILayout.LabelField("Seconds a request waits for a response before giving up, 0 waits forever");
+		var newTimeout = UnityEditor.EditorGUILayout.IntField("Timeout", castedTarget.Timeout);
+		if (newTimeout != castedTarget.Timeout) {
+			castedTarget.Timeout = System.Math.Max(0, newTimeout);
+			UnityEditor.EditorUtility.SetDirty(target);
+		}
+
+		UnityEditor.EditorGUILayout.Space();
+		serializedObject.Update();
+		UnityEditor.EditorGUILayout.PropertyField(serializedObject.FindProperty("retryPolicy"), true);
+		serializedObject.ApplyModifiedProperties();
+
 	}
 
 }
@@ -244,6 +280,60 @@ public class ServiceConfig: ScriptableObject, Config {
 	// The base URL to which the endpoint paths are appended
 	public string BasePath { get { return basePath; } set { basePath = value; } }
 
+	[SerializeField]
+	private int timeout;
+
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	public int Timeout { get { return timeout; } set { timeout = value; } }
+
+	[SerializeField]
+	private RetryPolicy retryPolicy = new RetryPolicy();
+
+	// How requests that fail are retried
+	public RetryPolicy RetryPolicy { get { return retryPolicy; } set { retryPolicy = value; } }
+
+	[System.NonSerialized]
+	private RequestInterceptors interceptors;
+
+	// Interceptors every request and response is run through
+	public RequestInterceptors Interceptors { get { if (interceptors == null) { interceptors = new RequestInterceptors(); } return interceptors; } }
+
+}
+
+// ApiClient is the entry point to every service, creating each one the
+// first time it's used
+public class ApiClient {
+
+	public Config Config { get; private set; }
+
+	public ApiClient(Config config) {
+		if (config == null) {
+			throw new System.ArgumentNullException("config");
+		}
+		this.Config = config;
+	}
+
+	// Load creates a client from the config with the name given, found in a
+	// Resources folder
+	public static ApiClient Load(string name = "ServiceConfig") {
+		var config = Resources.Load<ServiceConfig>(name);
+		if (config == null) {
+			throw new System.InvalidOperationException(string.Format("no ServiceConfig named \"{0}\" found in a Resources folder", name));
+		}
+		return new ApiClient(config);
+	}
+
+#if UNITY_ADDRESSABLES
+	// LoadAddressableAsync creates a client from the config at the address
+	// given, loaded through Addressables
+	public static async System.Threading.Tasks.Task<ApiClient> LoadAddressableAsync(string address = "ServiceConfig") {
+		var config = await UnityEngine.AddressableAssets.Addressables.LoadAssetAsync<ServiceConfig>(address).Task;
+		if (config == null) {
+			throw new System.InvalidOperationException(string.Format("no ServiceConfig found at the address \"{0}\"", address));
+		}
+		return new ApiClient(config);
+	}
+#endif
 }
 
 #endregion
@@ -291,15 +381,36 @@ public interface Config {
 	// The base URL to which the endpoint paths are appended
 	string BasePath { get; }
 
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	int Timeout { get; }
+
+	// How requests that fail are retried
+	RetryPolicy RetryPolicy { get; }
+
+	// Interceptors every request and response is run through
+	RequestInterceptors Interceptors { get; }
+
 }
 
-public interface IWebRequest {
+public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
+
+	void Abort();
 }
 
+`+path.ApiResponseClasses+`
+
+`+path.RetryPolicyClass+`
+
+`+path.InterceptorClasses+`
+
 #if UNITY_EDITOR
 [UnityEditor.CustomEditor(typeof(ServiceConfig))]
 public class ServiceConfigEditor : UnityEditor.Editor
@@ -322,6 +433,19 @@ public class ServiceConfigEditor : UnityEditor.Editor
 			UnityEditor.EditorUtility.SetDirty(target);
 		}
 
+		UnityEditor.EditorGUILayout.Space();
+		UnityEditor.EditorGUILayout.LabelField("Seconds a request waits for a response before giving up, 0 waits forever");
+		var newTimeout = UnityEditor.EditorGUILayout.IntField("Timeout", castedTarget.Timeout);
+		if (newTimeout != castedTarget.Timeout) {
+			castedTarget.Timeout = System.Math.Max(0, newTimeout);
+			UnityEditor.EditorUtility.SetDirty(target);
+		}
+
+		UnityEditor.EditorGUILayout.Space();
+		serializedObject.Update();
+		UnityEditor.EditorGUILayout.PropertyField(serializedObject.FindProperty("retryPolicy"), true);
+		serializedObject.ApplyModifiedProperties();
+
 	}
 
 }
@@ -337,6 +461,60 @@ public class ServiceConfig: ScriptableObject, Config {
 	// The base URL to which the endpoint paths are appended
 	public string BasePath { get { return basePath; } set { basePath = value; } }
 
+	[SerializeField]
+	private int timeout;
+
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	public int Timeout { get { return timeout; } set { timeout = value; } }
+
+	[SerializeField]
+	private RetryPolicy retryPolicy = new RetryPolicy();
+
+	// How requests that fail are retried
+	public RetryPolicy RetryPolicy { get { return retryPolicy; } set { retryPolicy = value; } }
+
+	[System.NonSerialized]
+	private RequestInterceptors interceptors;
+
+	// Interceptors every request and response is run through
+	public RequestInterceptors Interceptors { get { if (interceptors == null) { interceptors = new RequestInterceptors(); } return interceptors; } }
+
+}
+
+// ApiClient is the entry point to every service, creating each one the
+// first time it's used
+public class ApiClient {
+
+	public Config Config { get; private set; }
+
+	public ApiClient(Config config) {
+		if (config == null) {
+			throw new System.ArgumentNullException("config");
+		}
+		this.Config = config;
+	}
+
+	// Load creates a client from the config with the name given, found in a
+	// Resources folder
+	public static ApiClient Load(string name = "ServiceConfig") {
+		var config = Resources.Load<ServiceConfig>(name);
+		if (config == null) {
+			throw new System.InvalidOperationException(string.Format("no ServiceConfig named \"{0}\" found in a Resources folder", name));
+		}
+		return new ApiClient(config);
+	}
+
+#if UNITY_ADDRESSABLES
+	// LoadAddressableAsync creates a client from the config at the address
+	// given, loaded through Addressables
+	public static async System.Threading.Tasks.Task<ApiClient> LoadAddressableAsync(string address = "ServiceConfig") {
+		var config = await UnityEngine.AddressableAssets.Addressables.LoadAssetAsync<ServiceConfig>(address).Task;
+		if (config == null) {
+			throw new System.InvalidOperationException(string.Format("no ServiceConfig found at the address \"{0}\"", address));
+		}
+		return new ApiClient(config);
+	}
+#endif
 }
 
 #endregion
@@ -363,6 +541,87 @@ func TestSpecifyingOutWritesMultipleFiles(t *testing.T) {
 	AssertFileExists(t, appFS, "ServiceConfig.cs")
 }
 
+func TestSpecifyingOutWritesAFilePerCaller(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	appFS := afero.NewMemMapFs()
+	afero.WriteFile(appFS, "swagger.json", []byte(`{
+		"swagger": "2.0",
+		"paths": {
+			"/pets": {
+				"get": {
+					"operationId": "listPets",
+					"tags": ["pet"],
+					"responses": { "200": { "description": "ok" } }
+				}
+			}
+		}
+	}`), os.ModePerm)
+
+	out := strings.Builder{}
+	errOut := strings.Builder{}
+	app := buildApp(appFS, &out, &errOut)
+
+	// ********************************** ACT *********************************
+	err := app.Run([]string{"swag3d", "generate", "--file", "swagger.json", "--out", ".", "--callers"})
+
+	// ********************************* ASSERT *******************************
+	assert.NoError(t, err)
+	if AssertFileExists(t, appFS, "Callers/ListPetsCaller.cs") {
+		contents, _ := afero.ReadFile(appFS, "Callers/ListPetsCaller.cs")
+		assert.Contains(t, string(contents), "public class ListPetsCaller : MonoBehaviour {")
+	}
+}
+
+func TestCallersRequireScriptableObjectConfig(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	appFS := afero.NewMemMapFs()
+	afero.WriteFile(appFS, "swagger.json", []byte("{ }"), os.ModePerm)
+
+	out := strings.Builder{}
+	errOut := strings.Builder{}
+	app := buildApp(appFS, &out, &errOut)
+
+	// ********************************** ACT *********************************
+	err := app.Run([]string{"swag3d", "generate", "--file", "swagger.json", "--callers", "--scriptable-object-config=false"})
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, "callers reference the config from the Inspector, so they can't be generated without the scriptable object config")
+}
+
+func TestSpecifyingOutWritesVisualScripting(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	appFS := afero.NewMemMapFs()
+	afero.WriteFile(appFS, "swagger.json", []byte("{ }"), os.ModePerm)
+
+	out := strings.Builder{}
+	errOut := strings.Builder{}
+	app := buildApp(appFS, &out, &errOut)
+
+	// ********************************** ACT *********************************
+	err := app.Run([]string{"swag3d", "generate", "--file", "swagger.json", "--out", ".", "--visual-scripting"})
+
+	// ********************************* ASSERT *******************************
+	assert.NoError(t, err)
+	AssertFileExists(t, appFS, "VisualScriptingUnits.cs")
+	AssertFileExists(t, appFS, "Editor/VisualScriptingTypeOptions.cs")
+}
+
+func TestVisualScriptingRequiresOut(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	appFS := afero.NewMemMapFs()
+	afero.WriteFile(appFS, "swagger.json", []byte("{ }"), os.ModePerm)
+
+	out := strings.Builder{}
+	errOut := strings.Builder{}
+	app := buildApp(appFS, &out, &errOut)
+
+	// ********************************** ACT *********************************
+	err := app.Run([]string{"swag3d", "generate", "--file", "swagger.json", "--visual-scripting"})
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, "visual scripting units have to be written to a directory with --out, since their type options go in an Editor folder")
+}
+
 func TestErrorsWithNoFileToReadFrom(t *testing.T) {
 	// ******************************** ARRANGE *******************************
 	appFS := afero.NewMemMapFs()
@@ -413,6 +672,7 @@ func TestFilterUnusedDefinitions_AnonymouseFunctionReferences(t *testing.T) {
 							"200": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/Big")),
 						},
 						[]path.Parameter{},
+						path.PathOptions{},
 					),
 				},
 			),
@@ -426,3 +686,54 @@ func TestFilterUnusedDefinitions_AnonymouseFunctionReferences(t *testing.T) {
 	assert.Len(t, out.Services, 1)
 	assert.Len(t, out.Definitions, 3)
 }
+
+func TestFilterUnusedDefinitions_KeepsArraysOfDefinitions(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	bodyItem := model.NewObject("CreatePathsBodyItem", []model.Property{})
+	responseItem := model.NewObject("CreatePathsResponseItem", []model.Property{})
+
+	spec := unitygen.NewSpec(
+		unitygen.SpecInfo{},
+		[]model.Definition{
+			bodyItem,
+			responseItem,
+			model.NewObject("ToBeRemoved", nil),
+		},
+		nil,
+		[]unitygen.Service{
+			unitygen.NewService(
+				"A",
+				[]path.Path{
+					path.NewPath(
+						"aaerg",
+						"",
+						"",
+						nil,
+						nil,
+						map[string]path.Response{
+							"200": path.NewArrayResponse("", property.NewArray("", property.NewDefinitionReference("", responseItem))),
+						},
+						[]path.Parameter{
+							path.NewParameter(
+								path.BodyParameterLocation,
+								"body",
+								false,
+								property.NewArray("body", property.NewArray("body", property.NewDefinitionReference("body", bodyItem))),
+							),
+						},
+						path.PathOptions{},
+					),
+				},
+			),
+		},
+	)
+
+	// ********************************** ACT *********************************
+	out := filterSpecForUnusedDefinitions(spec)
+
+	// ********************************* ASSERT *******************************
+	if assert.Len(t, out.Definitions, 2) {
+		assert.Equal(t, "CreatePathsBodyItem", out.Definitions[0].Name())
+		assert.Equal(t, "CreatePathsResponseItem", out.Definitions[1].Name())
+	}
+}
@@ -0,0 +1,97 @@
+package unitygen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// ClientName is what the class gameplay code reaches every service through is
+// called, named after the API's title
+func (s Spec) ClientName() string {
+	// Punctuation splits words the same way spaces do
+	name := convention.TitleCase(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s.Info.Title))
+
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Api" + name
+	}
+	return name + "Client"
+}
+
+// clientProperty is what the client calls the service, which is its class
+// name without the "Service" at the end
+func (s Spec) clientProperty(service Service) string {
+	name := strings.TrimSuffix(service.className(), "Service")
+	if name == "" || name == "Config" {
+		return service.className()
+	}
+	return name
+}
+
+// Client prints out a C# class that owns a config and hands out every service
+// built with it, for gameplay code to use as its single entry point. Loading
+// the config by name is only possible when it's a scriptable object.
+func (s Spec) Client(configName string, includeScriptableObject bool) string {
+	clientName := s.ClientName()
+	properClassName := convention.TitleCase(configName)
+
+	builder := strings.Builder{}
+	builder.WriteString("// ")
+	builder.WriteString(clientName)
+	builder.WriteString(" is the entry point to every service, creating each one the\n")
+	builder.WriteString("// first time it's used\n")
+	fmt.Fprintf(&builder, "public class %s {\n\n", clientName)
+	builder.WriteString("\tpublic Config Config { get; private set; }\n\n")
+
+	for _, service := range s.Services {
+		property := s.clientProperty(service)
+		privateVarName := convention.CamelCase(property)
+		fmt.Fprintf(&builder, "\tprivate %s %s;\n\n", service.interfaceName(), privateVarName)
+		fmt.Fprintf(
+			&builder,
+			"\tpublic %s %s { get { if (%s == null) { %s = new %s(Config); } return %s; } }\n\n",
+			service.interfaceName(),
+			property,
+			privateVarName,
+			privateVarName,
+			service.className(),
+			privateVarName,
+		)
+	}
+
+	fmt.Fprintf(&builder, "\tpublic %s(Config config) {\n", clientName)
+	builder.WriteString("\t\tif (config == null) {\n\t\t\tthrow new System.ArgumentNullException(\"config\");\n\t\t}\n")
+	builder.WriteString("\t\tthis.Config = config;\n\t}\n")
+
+	if includeScriptableObject {
+		builder.WriteString("\n\t// Load creates a client from the config with the name given, found in a\n")
+		builder.WriteString("\t// Resources folder\n")
+		fmt.Fprintf(&builder, "\tpublic static %s Load(string name = %q) {\n", clientName, properClassName)
+		fmt.Fprintf(&builder, "\t\tvar config = Resources.Load<%s>(name);\n", properClassName)
+		builder.WriteString("\t\tif (config == null) {\n")
+		fmt.Fprintf(&builder, "\t\t\tthrow new System.InvalidOperationException(string.Format(\"no %s named \\\"{0}\\\" found in a Resources folder\", name));\n", properClassName)
+		builder.WriteString("\t\t}\n")
+		fmt.Fprintf(&builder, "\t\treturn new %s(config);\n\t}\n\n", clientName)
+
+		builder.WriteString("#if UNITY_ADDRESSABLES\n")
+		builder.WriteString("\t// LoadAddressableAsync creates a client from the config at the address\n")
+		builder.WriteString("\t// given, loaded through Addressables\n")
+		fmt.Fprintf(&builder, "\tpublic static async System.Threading.Tasks.Task<%s> LoadAddressableAsync(string address = %q) {\n", clientName, properClassName)
+		fmt.Fprintf(&builder, "\t\tvar config = await UnityEngine.AddressableAssets.Addressables.LoadAssetAsync<%s>(address).Task;\n", properClassName)
+		builder.WriteString("\t\tif (config == null) {\n")
+		fmt.Fprintf(&builder, "\t\t\tthrow new System.InvalidOperationException(string.Format(\"no %s found at the address \\\"{0}\\\"\", address));\n", properClassName)
+		builder.WriteString("\t\t}\n")
+		fmt.Fprintf(&builder, "\t\treturn new %s(config);\n\t}\n", clientName)
+		builder.WriteString("#endif\n")
+	}
+
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,98 @@
+package unitygen_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec_ClientName(t *testing.T) {
+	tests := map[string]struct {
+		title string
+		want  string
+	}{
+		"empty":       {title: "", want: "ApiClient"},
+		"single word": {title: "recolude", want: "RecoludeClient"},
+		"spaces":      {title: "Swagger Petstore", want: "SwaggerPetstoreClient"},
+		"punctuation": {title: "Pet-Store (v2)!", want: "PetStoreV2Client"},
+		"digit first": {title: "3D Store", want: "Api3DStoreClient"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := unitygen.Spec{Info: unitygen.SpecInfo{Title: tc.title}}
+			assert.Equal(t, tc.want, spec.ClientName())
+		})
+	}
+}
+
+func TestSpec_Client_LazilyCreatesEveryService(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	spec := unitygen.Spec{
+		Info: unitygen.SpecInfo{Title: "Petstore"},
+		Services: []unitygen.Service{
+			unitygen.NewService("pet", nil),
+			unitygen.NewService("storeService", nil),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := spec.Client("ServiceConfig", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `// PetstoreClient is the entry point to every service, creating each one the
+// first time it's used
+public class PetstoreClient {
+
+	public Config Config { get; private set; }
+
+	private IPetService pet;
+
+	public IPetService Pet { get { if (pet == null) { pet = new PetService(Config); } return pet; } }
+
+	private IStoreService store;
+
+	public IStoreService Store { get { if (store == null) { store = new StoreService(Config); } return store; } }
+
+	public PetstoreClient(Config config) {
+		if (config == null) {
+			throw new System.ArgumentNullException("config");
+		}
+		this.Config = config;
+	}
+}`, code)
+}
+
+func TestSpec_Client_KeepsServiceNameWhenItWouldClash(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	spec := unitygen.Spec{
+		Services: []unitygen.Service{
+			unitygen.NewService("config", nil),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := spec.Client("ServiceConfig", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "public IConfigService ConfigService { get { if (configService == null) { configService = new ConfigService(Config); } return configService; } }")
+}
+
+func TestSpec_Client_LoadsScriptableObjectConfig(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	spec := unitygen.Spec{}
+
+	// ********************************** ACT *********************************
+	withScriptableObject := spec.Client("RecoludeConfig", true)
+	withoutScriptableObject := spec.Client("RecoludeConfig", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, withScriptableObject, `	public static ApiClient Load(string name = "RecoludeConfig") {
+		var config = Resources.Load<RecoludeConfig>(name);`)
+	assert.Contains(t, withScriptableObject, `#if UNITY_ADDRESSABLES
+	// LoadAddressableAsync creates a client from the config at the address
+	// given, loaded through Addressables
+	public static async System.Threading.Tasks.Task<ApiClient> LoadAddressableAsync(string address = "RecoludeConfig") {`)
+	assert.NotContains(t, withoutScriptableObject, "Load")
+}
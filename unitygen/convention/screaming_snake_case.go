@@ -0,0 +1,32 @@
+package convention
+
+import "unicode"
+
+// ScreamingSnakeCase upper cases each word and joins them with '_', the way
+// environment variables are typically named.
+func ScreamingSnakeCase(in string) string {
+	out := make([]rune, 0)
+
+	runes := []rune(in)
+	for i, c := range runes {
+		if c == '_' || c == '-' || c == ' ' {
+			if len(out) > 0 && out[len(out)-1] != '_' {
+				out = append(out, '_')
+			}
+			continue
+		}
+
+		// A new word starts at each capital letter following a lowercase one
+		if unicode.IsUpper(c) && i > 0 && unicode.IsLower(runes[i-1]) && out[len(out)-1] != '_' {
+			out = append(out, '_')
+		}
+
+		out = append(out, unicode.ToUpper(c))
+	}
+
+	if len(out) > 0 && out[len(out)-1] == '_' {
+		out = out[:len(out)-1]
+	}
+
+	return string(out)
+}
@@ -0,0 +1,30 @@
+package convention_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreamingSnakeCase(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"simple":        {input: "abcDef", want: "ABC_DEF"},
+		"title case":    {input: "AdminAuthPassword", want: "ADMIN_AUTH_PASSWORD"},
+		"snake_case":    {input: "abc_def", want: "ABC_DEF"},
+		"kebab weird":   {input: "-abc--def-", want: "ABC_DEF"},
+		"spaces":        {input: " abc  def", want: "ABC_DEF"},
+		"acronym":       {input: "APIKey", want: "APIKEY"},
+		"already snake": {input: "ABC_DEF", want: "ABC_DEF"},
+		"empty string":  {input: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, convention.ScreamingSnakeCase(tc.input))
+		})
+	}
+}
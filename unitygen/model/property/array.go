@@ -28,6 +28,15 @@ func (sp Array) Property() model.Property {
 	return sp.prop
 }
 
+// innermostProperty digs through any arrays of arrays to find the property
+// that actually makes up the elements (ie: int[][] => int)
+func (sp Array) innermostProperty() model.Property {
+	if nested, ok := sp.prop.(Array); ok {
+		return nested.innermostProperty()
+	}
+	return sp.prop
+}
+
 func (sp Array) ToVariableType() string {
 	return fmt.Sprintf("%s[]", sp.prop.ToVariableType())
 }
@@ -38,6 +47,14 @@ func (sp Array) EmptyValue() string {
 
 func (sp Array) ClassVariables() string {
 	builder := strings.Builder{}
+
+	// Arrays of embedded objects need their class written out alongside them
+	if obj, ok := sp.innermostProperty().(Object); ok {
+		builder.WriteString("\t")
+		builder.WriteString(obj.Object().ToCSharp())
+		builder.WriteString("\n")
+	}
+
 	builder.WriteString("\t[JsonProperty(\"")
 	builder.WriteString(sp.name)
 	builder.WriteString("\")]\n\tpublic ")
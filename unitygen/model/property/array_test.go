@@ -3,6 +3,7 @@ package property_test
 import (
 	"testing"
 
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
 	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,3 +26,43 @@ func Test_Array(t *testing.T) {
 	public int[] MyArray { get; private set; }
 `, cSharp)
 }
+
+func Test_ArrayOfArrays(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	ref := property.NewArray("grid", property.NewArray("grid", property.NewNumber("cell", "")))
+
+	// ********************************** ACT *********************************
+	varType := ref.ToVariableType()
+	cSharp := ref.ClassVariables()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "float[][]", varType)
+	assert.Equal(t, `	[JsonProperty("grid")]
+	public float[][] Grid { get; private set; }
+`, cSharp)
+}
+
+func Test_ArrayWritesEmbeddedObjectClass(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	obj := model.NewObject("PathPointsItem", []model.Property{
+		property.NewNumber("x", ""),
+	})
+	ref := property.NewArray("points", property.NewArray("points", property.NewObject("points", obj)))
+
+	// ********************************** ACT *********************************
+	varType := ref.ToVariableType()
+	cSharp := ref.ClassVariables()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "PathPointsItem[][]", varType)
+	assert.Equal(t, `	[System.Serializable]
+public class PathPointsItem {
+
+	[JsonProperty("x")]
+	public float X { get; private set; }
+
+}
+	[JsonProperty("points")]
+	public PathPointsItem[][] Points { get; private set; }
+`, cSharp)
+}
@@ -20,22 +20,83 @@ import (
 type Parser struct {
 	workingDefinitions map[string]*model.DefinitionWrapper
 	resolvers          []resolver
+
+	// Whether or not objects defined inside of an array's items get written
+	// out as their own top level definition instead of nested inside the
+	// class that uses them
+	hoistArrayObjects bool
+
+	// Definitions that had to be created for objects embedded inside arrays
+	// and responses
+	embeddedDefinitions []model.Definition
+
+	// The mime types all routes produce unless they specify otherwise
+	defaultProduces []string
+
+	// The security all routes require unless they specify otherwise
+	defaultSecurity []path.SecurityRequirement
+
+	// The security schemes requirements are allowed to reference
+	securityDefinitions []security.Auth
 }
 
 func NewParser() *Parser {
 	return &Parser{
-		workingDefinitions: make(map[string]*model.DefinitionWrapper),
-		resolvers:          make([]resolver, 0),
+		workingDefinitions:  make(map[string]*model.DefinitionWrapper),
+		resolvers:           make([]resolver, 0),
+		embeddedDefinitions: make([]model.Definition, 0),
 	}
 }
 
-func (p *Parser) interpretArrayProperty(path []string, objectName, propertyName string, obj *gabs.Container) (property.Array, error) {
+// SetHoistArrayObjects determines whether or not objects embedded inside an
+// array definition get written out as top level definitions, or nested inside
+// the class that contains the array.
+func (p *Parser) SetHoistArrayObjects(hoist bool) {
+	p.hoistArrayObjects = hoist
+}
+
+// arrayItemClassName is the deterministic name given to an object embedded
+// inside an array, no matter how many arrays deep it is.
+func arrayItemClassName(objectName, propertyName string) string {
+	return fmt.Sprintf("%s%sItem", objectName, convention.ClassName(propertyName))
+}
+
+// interpretArrayProperty builds an array out of the items found. Objects
+// defined directly in the items are given a class name of
+// <Parent><Property>Item. When hoist is true that class becomes it's own
+// definition, otherwise the class will be nested inside of the parent object.
+func (p *Parser) interpretArrayProperty(path []string, objectName, propertyName string, obj *gabs.Container, hoist bool) (property.Array, error) {
 	items := obj.Path("items")
 	if items == nil {
 		return property.Array{}, InvalidSpecError{Path: path, Reason: "Unable to find array type (missing items property)"}
 	}
 
-	prop, err := p.interpretObjectDefinitionProperty(append(path, "items"), objectName, propertyName, items)
+	itemPath := append(path, "items")
+	_, hasRef := items.Path("$ref").Data().(string)
+	itemType, _ := items.Path("type").Data().(string)
+
+	if hasRef == false && itemType == "array" {
+		nested, err := p.interpretArrayProperty(itemPath, objectName, propertyName, items, hoist)
+		if err != nil {
+			return property.Array{}, err
+		}
+		return property.NewArray(propertyName, nested), nil
+	}
+
+	if hasRef == false && itemType == "object" {
+		def, err := p.interpretObjectDefinition(itemPath, arrayItemClassName(objectName, propertyName), items)
+		if err != nil {
+			return property.Array{}, err
+		}
+
+		if hoist {
+			p.embeddedDefinitions = append(p.embeddedDefinitions, def)
+			return property.NewArray(propertyName, property.NewDefinitionReference(propertyName, def)), nil
+		}
+		return property.NewArray(propertyName, property.NewObject(propertyName, def)), nil
+	}
+
+	prop, err := p.interpretObjectDefinitionProperty(itemPath, objectName, propertyName, items)
 	if err != nil {
 		return property.Array{}, err
 	}
@@ -103,7 +164,7 @@ func (p *Parser) interpretObjectDefinitionProperty(path []string, objectName, pr
 		return p.interpretStringProperty(path, propertyName, obj)
 
 	case "array":
-		return p.interpretArrayProperty(path, objectName, propertyName, obj)
+		return p.interpretArrayProperty(path, objectName, propertyName, obj, p.hoistArrayObjects)
 
 	case "integer":
 		return p.interpretIntProperty(path, propertyName, obj)
@@ -262,6 +323,12 @@ func (p *Parser) interpretAPIKeyDefinition(path []string, name string, obj *gabs
 	case "header":
 		keyLoc = security.Header
 
+	case "query":
+		keyLoc = security.Query
+
+	case "cookie":
+		keyLoc = security.Cookie
+
 	default:
 		return nil, InvalidSpecError{Path: keyPath, Reason: fmt.Sprintf("Unimplemnted key location: \"%s\"", foundIn)}
 	}
@@ -269,36 +336,188 @@ func (p *Parser) interpretAPIKeyDefinition(path []string, name string, obj *gabs
 	return security.NewAPIKey(name, apikeyName, keyLoc), nil
 }
 
-func (p *Parser) parseSecurityDefinitions(obj *gabs.Container) ([]security.Auth, error) {
-	definitions := make([]security.Auth, 0)
-	var err error
-	for key, val := range obj.Path("securityDefinitions").ChildrenMap() {
+// swagger2OAuth2Flows maps the flow names swagger 2.0 uses to the ones OpenAPI
+// 3 settled on
+var swagger2OAuth2Flows = map[string]security.OAuth2FlowType{
+	"implicit":    security.ImplicitFlow,
+	"password":    security.PasswordFlow,
+	"application": security.ClientCredentialsFlow,
+	"accessCode":  security.AuthorizationCodeFlow,
+}
 
-		definitionType, ok := val.Path("type").Data().(string)
+func (p *Parser) interpretOAuth2Flow(flowPath []string, flowType security.OAuth2FlowType, obj *gabs.Container) (security.OAuth2Flow, error) {
+	authorizationURL, _ := obj.Path("authorizationUrl").Data().(string)
+	tokenURL, _ := obj.Path("tokenUrl").Data().(string)
+	refreshURL, _ := obj.Path("refreshUrl").Data().(string)
+
+	if (flowType == security.ImplicitFlow || flowType == security.AuthorizationCodeFlow) && authorizationURL == "" {
+		return security.OAuth2Flow{}, InvalidSpecError{Path: flowPath, Reason: fmt.Sprintf("%s flow requires an authorizationUrl", flowType)}
+	}
+
+	if flowType != security.ImplicitFlow && tokenURL == "" {
+		return security.OAuth2Flow{}, InvalidSpecError{Path: flowPath, Reason: fmt.Sprintf("%s flow requires a tokenUrl", flowType)}
+	}
+
+	scopes := make(map[string]string)
+	for scope, descriptionNode := range obj.Path("scopes").ChildrenMap() {
+		description, _ := descriptionNode.Data().(string)
+		scopes[scope] = description
+	}
+
+	return security.NewOAuth2Flow(flowType, authorizationURL, tokenURL, refreshURL, scopes), nil
+}
+
+func (p *Parser) interpretOAuth2Definition(path []string, name string, obj *gabs.Container) (security.Auth, error) {
+	keyPath := append(path, name)
+	flows := make([]security.OAuth2Flow, 0)
+
+	// Swagger 2.0 has a single flow defined directly on the definition
+	if flowNode := obj.Path("flow"); flowNode != nil {
+		flowName, _ := flowNode.Data().(string)
+		flowType, ok := swagger2OAuth2Flows[flowName]
 		if !ok {
-			return nil, InvalidSpecError{Path: []string{"securityDefinitions", key}, Reason: "Definition type not found on definition"}
+			return nil, InvalidSpecError{Path: append(keyPath, "flow"), Reason: fmt.Sprintf("unknown oauth2 flow \"%s\"", flowName)}
 		}
+		flow, err := p.interpretOAuth2Flow(keyPath, flowType, obj)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, flow)
+	}
 
-		var def security.Auth
-		switch definitionType {
-		case "apiKey":
-			def, err = p.interpretAPIKeyDefinition([]string{"securityDefinitions"}, key, val)
-			break
-
+	// OpenAPI 3 allows for multiple flows
+	for flowName, flowNode := range obj.Path("flows").ChildrenMap() {
+		flowType := security.OAuth2FlowType(flowName)
+		switch flowType {
+		case security.ImplicitFlow, security.PasswordFlow, security.ClientCredentialsFlow, security.AuthorizationCodeFlow:
 		default:
-			return nil, InvalidSpecError{Path: []string{"securityDefinitions", key, "type"}, Reason: fmt.Sprintf("Unknown security type \"%s\"", definitionType)}
+			return nil, InvalidSpecError{Path: append(keyPath, "flows", flowName), Reason: fmt.Sprintf("unknown oauth2 flow \"%s\"", flowName)}
 		}
-
+		flow, err := p.interpretOAuth2Flow(append(keyPath, "flows", flowName), flowType, flowNode)
 		if err != nil {
 			return nil, err
 		}
-		definitions = append(definitions, def)
+		flows = append(flows, flow)
+	}
+
+	if len(flows) == 0 {
+		return nil, InvalidSpecError{Path: keyPath, Reason: "no oauth2 flows defined"}
+	}
+
+	return security.NewOAuth2(name, flows), nil
+}
+
+func (p *Parser) interpretHTTPDefinition(path []string, name string, obj *gabs.Container) (security.Auth, error) {
+	keyPath := append(path, name)
+
+	scheme, ok := obj.Path("scheme").Data().(string)
+	if !ok || scheme == "" {
+		return nil, InvalidSpecError{Path: keyPath, Reason: "No scheme found for http security"}
+	}
+
+	// Schemes are case insensitive
+	switch strings.ToLower(scheme) {
+	case "basic":
+		return security.NewBasicAuth(name), nil
+
+	case "bearer":
+		bearerFormat, _ := obj.Path("bearerFormat").Data().(string)
+		return security.NewBearerAuth(name, bearerFormat), nil
+	}
+
+	return nil, InvalidSpecError{Path: append(keyPath, "scheme"), Reason: fmt.Sprintf("Unimplemented http scheme \"%s\"", scheme)}
+}
+
+func (p *Parser) interpretOpenIDConnectDefinition(path []string, name string, obj *gabs.Container, scopes []string) (security.Auth, error) {
+	keyPath := append(path, name)
+
+	discoveryURL, ok := obj.Path("openIdConnectUrl").Data().(string)
+	if !ok || discoveryURL == "" {
+		return nil, InvalidSpecError{Path: keyPath, Reason: "No openIdConnectUrl found for OpenID Connect security"}
+	}
+
+	return security.NewOpenIDConnect(name, discoveryURL, scopes), nil
+}
+
+// requestedScopes collects every scope the spec's security requirements ask
+// of a security scheme, whether spec wide or on a single operation
+func requestedScopes(obj *gabs.Container, scheme string) []string {
+	requirements := obj.Path("security").Children()
+	for _, routeObj := range obj.Path("paths").ChildrenMap() {
+		for verb, verbObj := range routeObj.ChildrenMap() {
+			if isOperationKey(verb) {
+				requirements = append(requirements, verbObj.Path("security").Children()...)
+			}
+		}
+	}
+
+	found := make(map[string]bool)
+	scopes := make([]string, 0)
+	for _, requirement := range requirements {
+		for _, scope := range stringChildren(requirement.Search(scheme)) {
+			if found[scope] {
+				continue
+			}
+			found[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+func (p *Parser) parseSecurityDefinitions(obj *gabs.Container) ([]security.Auth, error) {
+	definitions := make([]security.Auth, 0)
+	var err error
+
+	// Swagger 2.0 calls them security definitions, OpenAPI 3 calls them
+	// security schemes
+	for _, definitionsPath := range [][]string{{"securityDefinitions"}, {"components", "securitySchemes"}} {
+		for key, val := range obj.Search(definitionsPath...).ChildrenMap() {
+			keyPath := append(append([]string{}, definitionsPath...), key)
+
+			definitionType, ok := val.Path("type").Data().(string)
+			if !ok {
+				return nil, InvalidSpecError{Path: keyPath, Reason: "Definition type not found on definition"}
+			}
+
+			var def security.Auth
+			switch definitionType {
+			case "apiKey":
+				def, err = p.interpretAPIKeyDefinition(definitionsPath, key, val)
+				break
+
+			case "oauth2":
+				def, err = p.interpretOAuth2Definition(definitionsPath, key, val)
+				break
+
+			case "basic":
+				def = security.NewBasicAuth(key)
+				break
+
+			case "http":
+				def, err = p.interpretHTTPDefinition(definitionsPath, key, val)
+				break
+
+			case "openIdConnect":
+				def, err = p.interpretOpenIDConnectDefinition(definitionsPath, key, val, requestedScopes(obj, key))
+				break
+
+			default:
+				return nil, InvalidSpecError{Path: append(keyPath, "type"), Reason: fmt.Sprintf("Unknown security type \"%s\"", definitionType)}
+			}
+
+			if err != nil {
+				return nil, err
+			}
+			definitions = append(definitions, def)
+		}
 	}
 
 	return definitions, nil
 }
 
-func (p *Parser) interpretPathParameterProperty(currentPath []string, name string, obj *gabs.Container) (model.Property, error) {
+func (p *Parser) interpretPathParameterProperty(currentPath []string, operationID, name string, obj *gabs.Container) (model.Property, error) {
 	schemaNode := obj.Path("schema")
 	if schemaNode != nil {
 
@@ -326,7 +545,7 @@ func (p *Parser) interpretPathParameterProperty(currentPath []string, name strin
 				return p.interpretStringProperty(currentPath, name, schemaNode)
 
 			case "array":
-				return p.interpretArrayProperty(currentPath, "", name, schemaNode)
+				return p.interpretArrayProperty(currentPath, convention.ClassName(operationID), name, schemaNode, true)
 
 			case "integer":
 				return p.interpretIntProperty(currentPath, name, schemaNode)
@@ -357,7 +576,7 @@ func (p *Parser) interpretPathParameterProperty(currentPath []string, name strin
 		return p.interpretStringProperty(currentPath, name, obj)
 
 	case "array":
-		return p.interpretArrayProperty(currentPath, "", name, obj)
+		return p.interpretArrayProperty(currentPath, convention.ClassName(operationID), name, obj, true)
 
 	case "integer":
 		return p.interpretIntProperty(currentPath, name, obj)
@@ -373,81 +592,204 @@ func (p *Parser) interpretPathParameterProperty(currentPath []string, name strin
 	}
 }
 
+// stringChildren collects all strings found in a JSON array
+func stringChildren(obj *gabs.Container) []string {
+	values := make([]string, 0)
+	for _, child := range obj.Children() {
+		if value, ok := child.Data().(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// producesPlainText determines whether or not a server will respond with plain
+// text instead of JSON given the mime types a route produces
+func producesPlainText(produces []string) bool {
+	plainText := false
+	for _, mimeType := range produces {
+		if mimeType == "application/json" || strings.HasSuffix(mimeType, "+json") {
+			return false
+		}
+		if mimeType == "text/plain" {
+			plainText = true
+		}
+	}
+	return plainText
+}
+
+func (p *Parser) interpretResponse(currentPath []string, operationID, code string, plainText bool, respJSON *gabs.Container) (path.Response, error) {
+	description := ""
+	descriptionNode := respJSON.Path("description")
+	if descriptionNode != nil {
+		description = descriptionNode.Data().(string)
+	}
+
+	schemaJSON := respJSON.Path("schema")
+	if schemaJSON == nil {
+		return nil, nil
+	}
+
+	refNode := schemaJSON.Path("$ref")
+	if refNode != nil {
+		return path.NewDefinitionResponse(
+			description,
+			model.NewDefinitionReference(refNode.Data().(string)),
+		), nil
+	}
+
+	typeNode := schemaJSON.Path("type")
+	if typeNode != nil {
+		typeValue := typeNode.Data().(string)
+		switch typeValue {
+		case "file":
+			return path.NewFileResponse(description), nil
+
+		case "number":
+			return path.NewNumberResponse(description), nil
+
+		case "integer":
+			return path.NewIntegerResponse(description), nil
+
+		case "boolean":
+			return path.NewBooleanResponse(description), nil
+
+		case "string":
+			return path.NewStringResponse(description, plainText), nil
+
+		case "array":
+			prop, err := p.interpretArrayProperty(currentPath, responseClassName(operationID, code), "", schemaJSON, true)
+			if err != nil {
+				return nil, err
+			}
+			return path.NewArrayResponse(description, prop), nil
+
+		case "object":
+			def, err := p.interpretObjectDefinition(currentPath, responseClassName(operationID, code), schemaJSON)
+			if err != nil {
+				return nil, err
+			}
+			p.embeddedDefinitions = append(p.embeddedDefinitions, def)
+			return path.NewDefinitionResponse(description, def), nil
+
+		default:
+			return nil, InvalidSpecError{Path: currentPath, Reason: "unable to interpret response schema: " + typeValue}
+		}
+	}
+
+	if len(schemaJSON.Children()) == 0 {
+		return nil, nil
+	}
+
+	return nil, InvalidSpecError{Path: currentPath, Reason: "unable to interpret response, schema missing both $ref and type definitions"}
+}
+
+func (p *Parser) interpretResponseHeaders(currentPath []string, operationID string, respJSON *gabs.Container) ([]path.ResponseHeader, error) {
+	headers := make([]path.ResponseHeader, 0)
+	for headerName, headerJSON := range respJSON.Path("headers").ChildrenMap() {
+		description, _ := headerJSON.Path("description").Data().(string)
+		headerProperty, err := p.interpretPathParameterProperty(currentPath, operationID, headerName, headerJSON)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, path.NewResponseHeader(headerName, description, headerProperty))
+	}
+	return headers, nil
+}
+
+// interpretSecurityRequirements reads a security array, where any one of the
+// entries authorizes a request, and every method within an entry is required.
+// Every method has to be one of the spec's security schemes.
+func (p *Parser) interpretSecurityRequirements(currentPath []string, obj *gabs.Container) ([]path.SecurityRequirement, error) {
+	requirements := make([]path.SecurityRequirement, 0)
+	for requirementIndex, child := range obj.Children() {
+		requirement := make(path.SecurityRequirement, 0)
+		for key, scopesNode := range child.ChildrenMap() {
+			if p.knownSecurityScheme(key) == false {
+				return nil, InvalidSpecError{
+					Path:   append(append([]string{}, currentPath...), fmt.Sprintf("[%d]", requirementIndex), key),
+					Reason: fmt.Sprintf("unknown security scheme '%s'", key),
+				}
+			}
+			ref := path.NewSecurityMethodReference(key)
+			ref.Contents = stringChildren(scopesNode)
+			requirement = append(requirement, ref)
+		}
+		sort.Sort(sortBySecurityReferenceIdentifier(requirement))
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// knownSecurityScheme is whether or not the spec defines the security scheme
+func (p *Parser) knownSecurityScheme(identifier string) bool {
+	for _, def := range p.securityDefinitions {
+		if def.Identifier() == identifier {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Parser) parsePaths(url string, routeObj *gabs.Container) ([]path.Path, error) {
 	paths := make([]path.Path, 0)
 	for verb, verbObj := range routeObj.ChildrenMap() {
+		if isOperationKey(verb) == false {
+			continue
+		}
+
 		tagsInJSON := make([]string, 0)
 		for _, child := range verbObj.Path("tags").Children() {
 			tagsInJSON = append(tagsInJSON, child.Data().(string))
 		}
 
-		securityReferences := make([]path.SecurityMethodReference, 0)
-		for _, child := range verbObj.Path("security").Children() {
-			for key := range child.ChildrenMap() {
-				securityReferences = append(securityReferences, path.NewSecurityMethodReference(key))
-			}
-		}
-		sort.Sort(sortBySecurityReferenceIdentifier(securityReferences))
-
 		operationID, ok := verbObj.Path("operationId").Data().(string)
 		if !ok {
 			return nil, InvalidSpecError{Path: []string{"paths", url, verb}, Reason: "unable to locate operation ID"}
 		}
 
-		responses := make(map[string]path.Response)
-		for code, respJSON := range verbObj.Path("responses").ChildrenMap() {
-			schemaJSON := respJSON.Path("schema")
-			description := ""
-			descriptionNode := respJSON.Path("description")
-			if descriptionNode != nil {
-				description = descriptionNode.Data().(string)
+		// An operation's security replaces the spec's, "security: []" opting
+		// out of it entirely
+		securityRequirements := p.defaultSecurity
+		if verbObj.Exists("security") {
+			var err error
+			securityRequirements, err = p.interpretSecurityRequirements([]string{"paths", url, verb, "security"}, verbObj.Path("security"))
+			if err != nil {
+				return nil, err
 			}
+		}
 
-			if schemaJSON != nil {
-				refNode := schemaJSON.Path("$ref")
-				if refNode != nil {
-					responses[code] = path.NewDefinitionResponse(
-						description,
-						model.NewDefinitionReference(refNode.Data().(string)),
-					)
-					continue
-				}
+		produces := p.defaultProduces
+		if verbObj.Exists("produces") {
+			produces = stringChildren(verbObj.Path("produces"))
+		}
 
-				typeNode := schemaJSON.Path("type")
-				if typeNode != nil {
-					typeValue := typeNode.Data().(string)
-					switch typeValue {
-					case "file":
-						responses[code] = path.NewFileResponse(description)
-
-					case "number":
-						responses[code] = path.NewNumberResponse(description)
-
-					case "array":
-						prop, err := p.interpretArrayProperty([]string{"paths", url, verb, code}, "", code, schemaJSON)
-						if err != nil {
-							return nil, err
-						}
-						responses[code] = path.NewArrayResponse(description, prop)
-
-					default:
-						return nil, InvalidSpecError{Path: []string{"paths", url, verb, "responses", code}, Reason: "unable to interpret response schema: " + typeValue}
-					}
-					continue
-				}
-				if len(schemaJSON.Children()) == 0 {
-					responses[code] = nil
-					continue
-				}
+		responses := make(map[string]path.Response)
+		responseHeaders := make(map[string][]path.ResponseHeader)
+		for code, respJSON := range verbObj.Path("responses").ChildrenMap() {
+			if path.IsValidResponseKey(code) == false {
+				return nil, InvalidSpecError{Path: []string{"paths", url, verb, "responses", code}, Reason: fmt.Sprintf("unrecognized response status code '%s'", code)}
+			}
+			resp, err := p.interpretResponse([]string{"paths", url, verb, "responses", code}, operationID, code, producesPlainText(produces), respJSON)
+			if err != nil {
+				return nil, err
+			}
+			responses[code] = resp
 
-				return nil, InvalidSpecError{Path: []string{"paths", url, verb, "responses", code}, Reason: "unable to interpret response, schema missing both $ref and type definitions"}
-			} else {
-				responses[code] = nil
+			headers, err := p.interpretResponseHeaders([]string{"paths", url, verb, "responses", code, "headers"}, operationID, respJSON)
+			if err != nil {
+				return nil, err
+			}
+			if len(headers) > 0 {
+				responseHeaders[code] = headers
 			}
 		}
 
 		parameters := make([]path.Parameter, 0)
-		for paramIndex, param := range verbObj.Path("parameters").Children() {
+		for _, specParam := range operationParameters(url, verb, routeObj, verbObj) {
+			param := specParam.obj
+			currentPath := specParam.path
+
 			required, ok := param.Path("required").Data().(bool)
 			if !ok {
 				required = false
@@ -455,7 +797,6 @@ func (p *Parser) parsePaths(url string, routeObj *gabs.Container) ([]path.Path,
 
 			paramName := param.Path("name").Data().(string)
 
-			currentPath := []string{url, verb, "parameters", fmt.Sprintf("[%d]", paramIndex)}
 			inNode := param.Path("in")
 			if inNode == nil {
 				return nil, InvalidSpecError{Path: currentPath, Reason: "missing 'in' definition (path, body, query)"}
@@ -477,6 +818,7 @@ func (p *Parser) parsePaths(url string, routeObj *gabs.Container) ([]path.Path,
 
 			paramProperty, err := p.interpretPathParameterProperty(
 				currentPath,
+				operationID,
 				paramName,
 				param,
 			)
@@ -492,23 +834,90 @@ func (p *Parser) parsePaths(url string, routeObj *gabs.Container) ([]path.Path,
 			))
 		}
 
-		paths = append(paths,
-			path.NewPath(
-				url,
-				operationID,
-				strings.ToUpper(verb),
-				tagsInJSON,
-				securityReferences,
-				responses,
-				parameters,
-			),
-		)
+		options := path.PathOptions{ResponseHeaders: responseHeaders}
+		if retry, ok := verbObj.Path("x-unity-retry").Data().(bool); ok {
+			options.Retry = &retry
+		}
+
+		paths = append(paths, path.NewPath(
+			url,
+			operationID,
+			strings.ToUpper(verb),
+			tagsInJSON,
+			securityRequirements,
+			responses,
+			parameters,
+			options,
+		))
 	}
 
 	sort.Sort(sortByPathMethod(paths))
 	return paths, nil
 }
 
+// specParameter is a parameter's JSON along with where it was found
+type specParameter struct {
+	path []string
+	obj  *gabs.Container
+}
+
+// parameterKey identifies a parameter by its name and location, which is what
+// an operation's parameter has to match to replace one of the path item's
+func parameterKey(param *gabs.Container) string {
+	name, _ := param.Path("name").Data().(string)
+	in, _ := param.Path("in").Data().(string)
+	return in + ":" + name
+}
+
+// operationParameters are the parameters shared by every operation of the
+// path item followed by the operation's own, an operation's parameter
+// overriding the path item's parameter with the same name and location
+func operationParameters(url, verb string, routeObj, verbObj *gabs.Container) []specParameter {
+	params := make([]specParameter, 0)
+	indices := make(map[string]int)
+	for paramIndex, param := range routeObj.Path("parameters").Children() {
+		indices[parameterKey(param)] = len(params)
+		params = append(params, specParameter{
+			path: []string{url, "parameters", fmt.Sprintf("[%d]", paramIndex)},
+			obj:  param,
+		})
+	}
+
+	for paramIndex, param := range verbObj.Path("parameters").Children() {
+		specParam := specParameter{
+			path: []string{url, verb, "parameters", fmt.Sprintf("[%d]", paramIndex)},
+			obj:  param,
+		}
+		if index, overrides := indices[parameterKey(param)]; overrides {
+			params[index] = specParam
+			continue
+		}
+		params = append(params, specParam)
+	}
+	return params
+}
+
+// isOperationKey determines whether or not a key found inside a path item is
+// an operation (get, patch, ...) as opposed to something shared across all
+// operations like parameters, summary, servers, or an extension (x-*).
+func isOperationKey(key string) bool {
+	switch strings.ToLower(key) {
+	case "parameters", "summary", "description", "servers", "$ref":
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(key), "x-") == false
+}
+
+// responseClassName is the name given to classes that have to be generated
+// for a specific response of an operation (ie: GetUserResponse,
+// GetUser404Response)
+func responseClassName(operationID, code string) string {
+	if code == "200" {
+		return fmt.Sprintf("%sResponse", convention.ClassName(operationID))
+	}
+	return fmt.Sprintf("%s%sResponse", convention.ClassName(operationID), convention.TitleCase(code))
+}
+
 type sortBySecurityReferenceIdentifier []path.SecurityMethodReference
 
 func (a sortBySecurityReferenceIdentifier) Len() int      { return len(a) }
@@ -619,10 +1028,19 @@ func (p *Parser) ParseJSON(in io.Reader) (Spec, error) {
 		return Spec{}, err
 	}
 
+	p.securityDefinitions = parsedSecurityDefinitions
+	p.defaultSecurity, err = p.interpretSecurityRequirements([]string{"security"}, jsonParsed.Path("security"))
+	if err != nil {
+		return Spec{}, err
+	}
+
+	p.defaultProduces = stringChildren(jsonParsed.Path("produces"))
+
 	parsedServices, err := p.parseServices(jsonParsed)
 	if err != nil {
 		return Spec{}, err
 	}
+	parsedDefinitions = append(parsedDefinitions, p.embeddedDefinitions...)
 
 	return NewSpec(info, parsedDefinitions, parsedSecurityDefinitions, parsedServices), nil
 }
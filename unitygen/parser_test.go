@@ -7,6 +7,7 @@ import (
 
 	"github.com/recolude/swagger-unity-codegen/unitygen"
 	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -330,6 +331,11 @@ func Test_ReadParameters(t *testing.T) {
 			"title": "Read Params",
 			"version": "1.0.1"
 		},
+		"securityDefinitions": {
+			"ApiKeyAuth": { "type": "apiKey", "in": "header", "name": "X-API-KEY" },
+			"CognitoAuth": { "type": "apiKey", "in": "header", "name": "Authorization" },
+			"DevKeyAuth": { "type": "apiKey", "in": "header", "name": "X-DEV-KEY" }
+		},
 		"paths": {
 			"/api/v1/echo": {
 				"get": {
@@ -929,3 +935,821 @@ public class GraphQuery {
 }`, graphQueryDef.ToCSharp())
 	}
 }
+
+func Test_ReadArrayOfEmbeddedObjects_Nested(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+			"paths": {
+			},
+			"definitions": {
+				"Path": {
+					"type": "object",
+					"properties": {
+						"points": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"properties": {
+									"x": { "type": "number" }
+								}
+							}
+						},
+						"grid": {
+							"type": "array",
+							"items": {
+								"type": "array",
+								"items": {
+									"type": "object",
+									"properties": {
+										"on": { "type": "boolean" }
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+	if assert.Len(t, spec.Definitions, 1) {
+		assert.Equal(t, `[System.Serializable]
+public class Path {
+
+	[System.Serializable]
+public class PathGridItem {
+
+	[JsonProperty("on")]
+	public bool On { get; private set; }
+
+}
+	[JsonProperty("grid")]
+	public PathGridItem[][] Grid { get; private set; }
+
+	[System.Serializable]
+public class PathPointsItem {
+
+	[JsonProperty("x")]
+	public float X { get; private set; }
+
+}
+	[JsonProperty("points")]
+	public PathPointsItem[] Points { get; private set; }
+
+}`, spec.Definitions[0].ToCSharp())
+	}
+}
+
+func Test_ReadArrayOfEmbeddedObjects_Hoisted(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+			"paths": {
+				"/paths": {
+					"post": {
+						"operationId": "createPaths",
+						"parameters": [
+							{
+								"name": "body",
+								"in": "body",
+								"schema": {
+									"type": "array",
+									"items": {
+										"type": "object",
+										"properties": {
+											"name": { "type": "string" }
+										}
+									}
+								}
+							}
+						],
+						"responses": {
+							"200": {
+								"schema": {
+									"type": "array",
+									"items": {
+										"type": "object",
+										"properties": {
+											"id": { "type": "string" }
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			},
+			"definitions": {
+				"Path": {
+					"type": "object",
+					"properties": {
+						"points": {
+							"type": "array",
+							"items": {
+								"type": "object",
+								"properties": {
+									"x": { "type": "number" }
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	parser.SetHoistArrayObjects(true)
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+	if assert.Len(t, spec.Definitions, 4) {
+		assert.Equal(t, "CreatePathsBodyItem", spec.Definitions[0].Name())
+		assert.Equal(t, "CreatePathsResponseItem", spec.Definitions[1].Name())
+		assert.Equal(t, "Path", spec.Definitions[2].Name())
+		assert.Equal(t, "PathPointsItem", spec.Definitions[3].Name())
+		assert.Equal(t, `[System.Serializable]
+public class Path {
+
+	[JsonProperty("points")]
+	public PathPointsItem[] Points { get; private set; }
+
+}`, spec.Definitions[2].ToCSharp())
+	}
+
+	if assert.Len(t, spec.Services, 1) && assert.Len(t, spec.Services[0].Paths(), 1) {
+		route := spec.Services[0].Paths()[0]
+		assert.Equal(t, "CreatePathsResponseItem[]", route.Responses()["200"].VariableType())
+		if assert.Len(t, route.Parameters(), 1) {
+			assert.Equal(t, "CreatePathsBodyItem[]", route.Parameters()[0].Schema().ToVariableType())
+		}
+	}
+}
+
+func Test_ReadPrimitiveAndInlineObjectResponses(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"produces": ["application/json"],
+		"paths": {
+			"/status": {
+				"get": {
+					"operationId": "getStatus",
+					"produces": ["text/plain"],
+					"responses": {
+						"200": {
+							"description": "Status message",
+							"schema": { "type": "string" }
+						}
+					}
+				},
+				"post": {
+					"operationId": "checkStatus",
+					"responses": {
+						"200": {
+							"schema": {
+								"type": "object",
+								"properties": {
+									"healthy": { "type": "boolean" }
+								}
+							}
+						},
+						"400": {
+							"schema": { "type": "string" }
+						},
+						"404": {
+							"schema": { "type": "boolean" }
+						},
+						"500": {
+							"schema": { "type": "integer" }
+						}
+					}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+
+	if assert.Len(t, spec.Definitions, 1) {
+		assert.Equal(t, `[System.Serializable]
+public class CheckStatusResponse {
+
+	[JsonProperty("healthy")]
+	public bool Healthy { get; private set; }
+
+}`, spec.Definitions[0].ToCSharp())
+	}
+
+	if assert.Len(t, spec.Services, 1) && assert.Len(t, spec.Services[0].Paths(), 2) {
+		getStatus := spec.Services[0].Paths()[0]
+		assert.Equal(t, "getStatus", getStatus.OperationID())
+		assert.Equal(t, "string", getStatus.Responses()["200"].VariableType())
+		assert.Equal(t, "Status message", getStatus.Responses()["200"].Description())
+		assert.Equal(t, "success = req.downloadHandler.text;", getStatus.Responses()["200"].Interpret("success", "req.downloadHandler"))
+
+		checkStatus := spec.Services[0].Paths()[1]
+		assert.Equal(t, "checkStatus", checkStatus.OperationID())
+		assert.Equal(t, "CheckStatusResponse", checkStatus.Responses()["200"].VariableType())
+		assert.Equal(t, "string", checkStatus.Responses()["400"].VariableType())
+		assert.Equal(t, "badRequest = JsonConvert.DeserializeObject<string>(req.downloadHandler.text);", checkStatus.Responses()["400"].Interpret("badRequest", "req.downloadHandler"))
+		assert.Equal(t, "bool", checkStatus.Responses()["404"].VariableType())
+		assert.Equal(t, "int", checkStatus.Responses()["500"].VariableType())
+	}
+}
+
+func Test_ErrorsOnUnrecognizedResponseKey(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"paths": {
+			"/status": {
+				"get": {
+					"operationId": "getStatus",
+					"responses": {
+						"ok": {
+							"schema": { "type": "string" }
+						}
+					}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	_, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, "Invalid spec at paths./status.get.responses.ok: unrecognized response status code 'ok'")
+}
+
+func Test_ReadResponseHeaders(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"paths": {
+			"/recordings": {
+				"post": {
+					"operationId": "createRecording",
+					"responses": {
+						"201": {
+							"description": "Created",
+							"headers": {
+								"Location": {
+									"type": "string",
+									"description": "Where the recording lives"
+								},
+								"X-RateLimit-Remaining": {
+									"type": "integer",
+									"format": "int32"
+								}
+							}
+						},
+						"429": {
+							"headers": {
+								"Retry-After": {
+									"schema": { "type": "integer" }
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+	if assert.Len(t, spec.Services, 1) && assert.Len(t, spec.Services[0].Paths(), 1) {
+		headers := spec.Services[0].Paths()[0].ResponseHeaders()
+		assert.Len(t, headers, 2)
+		if assert.Len(t, headers["201"], 2) {
+			assert.ElementsMatch(t, []string{"Location", "X-RateLimit-Remaining"}, []string{headers["201"][0].Name(), headers["201"][1].Name()})
+		}
+		if assert.Len(t, headers["429"], 1) {
+			assert.Equal(t, "Retry-After", headers["429"][0].Name())
+			assert.Equal(t, "int?", headers["429"][0].VariableType())
+		}
+	}
+}
+
+func Test_ReadsPatchOptionsAndSkipsNonOperationKeys(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"paths": {
+			"/recordings/{id}": {
+				"summary": "A single recording",
+				"description": "Operations on a single recording",
+				"servers": [ { "url": "https://api.example.com" } ],
+				"parameters": [ { "name": "id", "in": "path", "required": true, "type": "string" } ],
+				"x-internal": true,
+				"patch": {
+					"operationId": "updateRecording",
+					"responses": { "204": { "description": "Updated" } }
+				},
+				"options": {
+					"operationId": "recordingOptions",
+					"responses": { "204": { "description": "Allowed" } }
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+	if assert.Len(t, spec.Services, 1) && assert.Len(t, spec.Services[0].Paths(), 2) {
+		assert.Equal(t, "OPTIONS", spec.Services[0].Paths()[0].Method())
+		assert.Equal(t, "recordingOptions", spec.Services[0].Paths()[0].OperationID())
+		assert.Equal(t, "PATCH", spec.Services[0].Paths()[1].Method())
+		assert.Equal(t, "updateRecording", spec.Services[0].Paths()[1].OperationID())
+	}
+}
+
+func Test_PathItemParametersAreMergedIntoOperations(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"paths": {
+			"/recordings/{id}": {
+				"parameters": [
+					{ "name": "id", "in": "path", "required": true, "type": "string" },
+					{ "name": "fields", "in": "query", "type": "string" }
+				],
+				"get": {
+					"operationId": "getRecording",
+					"responses": { "204": { "description": "Found" } }
+				},
+				"patch": {
+					"operationId": "updateRecording",
+					"parameters": [
+						{ "name": "fields", "in": "query", "required": true, "type": "integer" }
+					],
+					"responses": { "204": { "description": "Updated" } }
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false {
+		return
+	}
+	if assert.Len(t, spec.Services, 1) == false || assert.Len(t, spec.Services[0].Paths(), 2) == false {
+		return
+	}
+
+	get := spec.Services[0].Paths()[0]
+	assert.Equal(t, "GET", get.Method())
+	if assert.Len(t, get.Parameters(), 2) {
+		assert.Equal(t, "id", get.Parameters()[0].Name())
+		assert.Equal(t, path.PathParameterLocation, get.Parameters()[0].Location())
+		assert.Equal(t, "fields", get.Parameters()[1].Name())
+		assert.False(t, get.Parameters()[1].Required())
+	}
+	assert.Contains(t, get.RequestParamClass(), `finalPath = finalPath.Replace("{id}", `)
+
+	patch := spec.Services[0].Paths()[1]
+	assert.Equal(t, "PATCH", patch.Method())
+	if assert.Len(t, patch.Parameters(), 2) {
+		assert.Equal(t, "id", patch.Parameters()[0].Name())
+		assert.Equal(t, "fields", patch.Parameters()[1].Name())
+		assert.Equal(t, path.QueryParameterLocation, patch.Parameters()[1].Location())
+		assert.True(t, patch.Parameters()[1].Required())
+		assert.Equal(t, "int", patch.Parameters()[1].Schema().ToVariableType())
+	}
+	assert.Contains(t, patch.RequestParamClass(), `finalPath = finalPath.Replace("{id}", `)
+}
+
+func Test_ReadOAuth2SecurityDefinitions(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"securityDefinitions": {
+			"legacyAuth": {
+				"type": "oauth2",
+				"flow": "application",
+				"tokenUrl": "https://example.com/token",
+				"scopes": {
+					"read:recordings": "Read recordings"
+				}
+			},
+			"playerAuth": {
+				"type": "oauth2",
+				"flows": {
+					"password": {
+						"tokenUrl": "https://example.com/token",
+						"refreshUrl": "https://example.com/refresh",
+						"scopes": {}
+					},
+					"authorizationCode": {
+						"authorizationUrl": "https://example.com/authorize",
+						"tokenUrl": "https://example.com/token",
+						"scopes": {
+							"write:recordings": "Upload recordings"
+						}
+					}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.AuthDefinitions, 2) == false {
+		return
+	}
+
+	legacy, ok := spec.AuthDefinitions[0].(security.OAuth2)
+	if assert.True(t, ok) && assert.Len(t, legacy.Flows(), 1) {
+		assert.Equal(t, "legacyAuth", legacy.Identifier())
+		assert.Equal(t, security.ClientCredentialsFlow, legacy.Flows()[0].Type())
+		assert.Equal(t, "https://example.com/token", legacy.Flows()[0].TokenURL())
+		assert.Equal(t, map[string]string{"read:recordings": "Read recordings"}, legacy.Flows()[0].Scopes())
+	}
+
+	player, ok := spec.AuthDefinitions[1].(security.OAuth2)
+	if assert.True(t, ok) && assert.Len(t, player.Flows(), 2) {
+		assert.Equal(t, "playerAuth", player.Identifier())
+		assert.Equal(t, security.AuthorizationCodeFlow, player.Flows()[0].Type())
+		assert.Equal(t, "https://example.com/authorize", player.Flows()[0].AuthorizationURL())
+		assert.Equal(t, security.PasswordFlow, player.Flows()[1].Type())
+		assert.Equal(t, "https://example.com/refresh", player.Flows()[1].RefreshURL())
+	}
+}
+
+func Test_ErrorsOnOAuth2FlowMissingTokenURL(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"securityDefinitions": {
+			"playerAuth": {
+				"type": "oauth2",
+				"flows": {
+					"password": {
+						"scopes": {}
+					}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	_, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, "Invalid spec at securityDefinitions.playerAuth.flows.password: password flow requires a tokenUrl")
+}
+
+func Test_ReadOpenIDConnectSecurityDefinitions(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"components": {
+			"securitySchemes": {
+				"playerAuth": {
+					"type": "openIdConnect",
+					"openIdConnectUrl": "https://example.com/.well-known/openid-configuration"
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.AuthDefinitions, 1) == false {
+		return
+	}
+
+	oidc, ok := spec.AuthDefinitions[0].(security.OpenIDConnect)
+	if assert.True(t, ok) {
+		assert.Equal(t, "playerAuth", oidc.Identifier())
+		assert.Equal(t, "https://example.com/.well-known/openid-configuration", oidc.DiscoveryURL())
+	}
+}
+
+func Test_OpenIDConnectRequestsScopesFromSecurityRequirements(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"security": [{"playerAuth": ["profile"]}],
+		"paths": {
+			"/api/v1/recordings": {
+				"get": {
+					"operationId": "listRecordings",
+					"responses": {}
+				},
+				"post": {
+					"operationId": "createRecording",
+					"security": [{"playerAuth": ["write:recordings", "profile"]}, {"adminAuth": ["admin"]}],
+					"responses": {}
+				}
+			}
+		},
+		"components": {
+			"securitySchemes": {
+				"playerAuth": {
+					"type": "openIdConnect",
+					"openIdConnectUrl": "https://example.com/.well-known/openid-configuration"
+				},
+				"adminAuth": {
+					"type": "openIdConnect",
+					"openIdConnectUrl": "https://admin.example.com/.well-known/openid-configuration"
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.AuthDefinitions, 2) == false {
+		return
+	}
+
+	scopes := make(map[string]string)
+	for _, def := range spec.AuthDefinitions {
+		if oidc, ok := def.(security.OpenIDConnect); assert.True(t, ok) {
+			scopes[oidc.Identifier()] = oidc.Scopes()
+		}
+	}
+	assert.Equal(t, "openid profile write:recordings", scopes["playerAuth"])
+	assert.Equal(t, "openid admin", scopes["adminAuth"])
+}
+
+func Test_ErrorsOnOpenIDConnectMissingURL(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"components": {
+			"securitySchemes": {
+				"playerAuth": {
+					"type": "openIdConnect"
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	_, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, "Invalid spec at components.securitySchemes.playerAuth: No openIdConnectUrl found for OpenID Connect security")
+}
+
+func Test_ReadBasicAndBearerSecurityDefinitions(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"securityDefinitions": {
+			"legacyBasic": {
+				"type": "basic"
+			}
+		},
+		"components": {
+			"securitySchemes": {
+				"adminBasic": {
+					"type": "http",
+					"scheme": "Basic"
+				},
+				"sessionAuth": {
+					"type": "http",
+					"scheme": "bearer",
+					"bearerFormat": "JWT"
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.AuthDefinitions, 3) == false {
+		return
+	}
+	assert.Equal(t, security.NewBasicAuth("adminBasic"), spec.AuthDefinitions[0])
+	assert.Equal(t, security.NewBasicAuth("legacyBasic"), spec.AuthDefinitions[1])
+	assert.Equal(t, security.NewBearerAuth("sessionAuth", "JWT"), spec.AuthDefinitions[2])
+}
+
+func Test_ErrorsOnUnknownHTTPScheme(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"components": {
+			"securitySchemes": {
+				"digestAuth": {
+					"type": "http",
+					"scheme": "digest"
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	_, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	assert.EqualError(t, err, `Invalid spec at components.securitySchemes.digestAuth.scheme: Unimplemented http scheme "digest"`)
+}
+
+func Test_ReadAPIKeysInQueryAndCookie(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"securityDefinitions": {
+			"analyticsKey": {
+				"type": "apiKey",
+				"in": "query",
+				"name": "api_key"
+			},
+			"partnerKey": {
+				"type": "apiKey",
+				"in": "cookie",
+				"name": "PARTNER_SESSION"
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.AuthDefinitions, 2) == false {
+		return
+	}
+	assert.Equal(t, security.NewAPIKey("analyticsKey", "api_key", security.Query), spec.AuthDefinitions[0])
+	assert.Equal(t, security.NewAPIKey("partnerKey", "PARTNER_SESSION", security.Cookie), spec.AuthDefinitions[1])
+}
+
+func Test_ReadSecurityRequirements(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"securityDefinitions": {
+			"DevKeyAuth": { "type": "apiKey", "in": "header", "name": "X-DEV-KEY" },
+			"CognitoAuth": { "type": "apiKey", "in": "header", "name": "X-COGNITO" },
+			"PlayerAuth": {
+				"type": "oauth2",
+				"flow": "password",
+				"tokenUrl": "https://example.com/token",
+				"scopes": { "read:recordings": "Read recordings" }
+			}
+		},
+		"security": [
+			{ "DevKeyAuth": [] }
+		],
+		"paths": {
+			"/recordings": {
+				"get": {
+					"operationId": "listRecordings",
+					"responses": {}
+				},
+				"post": {
+					"operationId": "createRecording",
+					"security": [
+						{ "PlayerAuth": ["read:recordings"], "CognitoAuth": [] },
+						{ "DevKeyAuth": [] }
+					],
+					"responses": {}
+				}
+			},
+			"/health": {
+				"get": {
+					"operationId": "health",
+					"security": [],
+					"responses": {}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.Services, 1) == false {
+		return
+	}
+
+	requirements := make(map[string][]path.SecurityRequirement)
+	for _, p := range spec.Services[0].Paths() {
+		requirements[p.OperationID()] = p.SecurityRequirements()
+	}
+
+	assert.Equal(t, []path.SecurityRequirement{
+		{path.SecurityMethodReference{Identifier: "DevKeyAuth", Contents: []string{}}},
+	}, requirements["listRecordings"])
+
+	assert.Equal(t, []path.SecurityRequirement{
+		{
+			path.SecurityMethodReference{Identifier: "CognitoAuth", Contents: []string{}},
+			path.SecurityMethodReference{Identifier: "PlayerAuth", Contents: []string{"read:recordings"}},
+		},
+		{path.SecurityMethodReference{Identifier: "DevKeyAuth", Contents: []string{}}},
+	}, requirements["createRecording"])
+
+	assert.Empty(t, requirements["health"])
+}
+
+func Test_ErrorsOnSecurityRequirementsForUnknownSchemes(t *testing.T) {
+	tests := map[string]struct {
+		swaggerDotJSON string
+		err            string
+	}{
+		"spec wide": {
+			swaggerDotJSON: `{
+				"security": [ { "missing": [] } ],
+				"paths": {}
+			}`,
+			err: "Invalid spec at security.[0].missing: unknown security scheme 'missing'",
+		},
+		"operation": {
+			swaggerDotJSON: `{
+				"securityDefinitions": {
+					"apiKey": { "type": "apiKey", "in": "header", "name": "X-API-KEY" }
+				},
+				"paths": {
+					"/recordings": {
+						"get": {
+							"operationId": "listRecordings",
+							"security": [ { "apiKey": [] }, { "apiKey": [], "missing": [] } ],
+							"responses": { "204": { "description": "Listed" } }
+						}
+					}
+				}
+			}`,
+			err: "Invalid spec at paths./recordings.get.security.[1].missing: unknown security scheme 'missing'",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ********************************** ACT *********************************
+			parser := unitygen.NewParser()
+			_, err := parser.ParseJSON(strings.NewReader(tc.swaggerDotJSON))
+
+			// ********************************* ASSERT *******************************
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func Test_ReadRetryExtension(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	swaggerDotJSON := `{
+		"paths": {
+			"/recordings": {
+				"get": {
+					"operationId": "listRecordings",
+					"responses": {}
+				},
+				"post": {
+					"operationId": "createRecording",
+					"x-unity-retry": true,
+					"responses": {}
+				},
+				"delete": {
+					"operationId": "deleteRecordings",
+					"x-unity-retry": false,
+					"responses": {}
+				}
+			}
+		}
+	}`
+	// ********************************** ACT *********************************
+	parser := unitygen.NewParser()
+	spec, err := parser.ParseJSON(strings.NewReader(swaggerDotJSON))
+
+	// ********************************* ASSERT *******************************
+	if assert.NoError(t, err) == false || assert.Len(t, spec.Services, 1) == false {
+		return
+	}
+
+	retries := make(map[string]bool)
+	for _, p := range spec.Services[0].Paths() {
+		retries[p.OperationID()] = p.Retries()
+	}
+
+	assert.True(t, retries["listRecordings"])
+	assert.True(t, retries["createRecording"])
+	assert.False(t, retries["deleteRecordings"])
+}
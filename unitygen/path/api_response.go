@@ -0,0 +1,171 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ApiResponseClasses is the C# every request's result is built from
+const ApiResponseClasses = `// IApiResponse is what every request's result has in common, whatever type
+// its bodies are
+public interface IApiResponse {
+
+	long StatusCode { get; }
+
+	bool IsSuccess { get; }
+
+	string NetworkError { get; }
+
+	bool TimedOut { get; }
+
+	bool IsHttpError { get; }
+
+	bool Cancelled { get; }
+
+	System.Exception ParseException { get; }
+
+	string Text { get; }
+
+	byte[] Bytes { get; }
+}
+
+// ApiResponse is the result of a request, holding whichever body the server
+// sent back along with anything that went wrong getting it
+public class ApiResponse<TSuccess, TError> : IApiResponse {
+
+	// Status code of the response, 0 when the server was never reached
+	public long StatusCode { get; private set; }
+
+	// Whether or not the server responded with a 2XX that was interpreted
+	// without any problems
+	public bool IsSuccess { get { return Cancelled == false && NetworkError == null && ParseException == null && StatusCode >= 200 && StatusCode < 300; } }
+
+	// Body of a successful response, if there was one
+	public TSuccess Success { get; internal set; }
+
+	// Body of an unsuccessful response, if there was one
+	public TError Error { get; internal set; }
+
+	// Why the server couldn't be reached (DNS failures, dropped connections,
+	// timeouts, a retry that couldn't be rebuilt), if it couldn't
+	public string NetworkError { get; private set; }
+
+	// Whether or not the request gave up after the config's timeout
+	public bool TimedOut { get; private set; }
+
+	// Whether or not the server responded with an error status code
+	public bool IsHttpError { get; private set; }
+
+	// Whether or not the request was aborted before it finished
+	public bool Cancelled { get; internal set; }
+
+	// What went wrong interpreting the response, if anything
+	public System.Exception ParseException { get; internal set; }
+
+	// Body of the response exactly as it was received
+	public byte[] Bytes { get; private set; }
+
+	public string Text { get { return Bytes == null ? null : Encoding.UTF8.GetString(Bytes); } }
+
+	public ApiResponse(UnityWebRequest req) : this(req, 0f) { }
+
+	// ApiResponse for a request that came back the given number of seconds
+	// after being sent, which is how a timeout is told apart from the other
+	// network errors whatever language Unity words its errors in
+	public ApiResponse(UnityWebRequest req, float elapsed) {
+		this.StatusCode = req.responseCode;
+		this.Bytes = req.downloadHandler == null ? null : req.downloadHandler.data;
+
+#if UNITY_2020_2_OR_NEWER
+		var networkFailed = req.result == UnityWebRequest.Result.ConnectionError || req.result == UnityWebRequest.Result.DataProcessingError;
+		this.IsHttpError = req.result == UnityWebRequest.Result.ProtocolError;
+#else
+		var networkFailed = req.isNetworkError;
+		this.IsHttpError = req.isHttpError;
+#endif
+
+		if (networkFailed) {
+			this.NetworkError = string.IsNullOrEmpty(req.error) ? "unable to reach the server" : req.error;
+			this.TimedOut = req.timeout > 0 && elapsed >= req.timeout;
+		}
+	}
+
+	// ApiResponse for a response that never went over the network, like the
+	// ones fake services answer with
+	public ApiResponse(long statusCode) {
+		this.StatusCode = statusCode;
+		this.IsHttpError = statusCode >= 400;
+	}
+
+	// ApiResponse for a request that couldn't be sent at all because of the
+	// exception
+	public ApiResponse(System.Exception exception) {
+		this.NetworkError = exception.Message;
+	}
+}`
+
+// isSuccessCode is whether or not the response key covers 2XX status codes
+func isSuccessCode(code string) bool {
+	return strings.HasPrefix(code, "2")
+}
+
+// bodyType is the one type every response with a body that matches the filter
+// shares, falling back to object when they differ or there aren't any
+func (p Path) bodyType(filter func(code string) bool) string {
+	found := ""
+	for _, code := range p.orderedResponseCodes() {
+		if p.responses[code] == nil || filter(code) == false {
+			continue
+		}
+		if found != "" && found != p.responses[code].VariableType() {
+			return "object"
+		}
+		found = p.responses[code].VariableType()
+	}
+	if found == "" {
+		return "object"
+	}
+	return found
+}
+
+func (p Path) successBodyType() string {
+	return p.bodyType(isSuccessCode)
+}
+
+func (p Path) errorBodyType() string {
+	return p.bodyType(func(code string) bool { return isSuccessCode(code) == false })
+}
+
+func (p Path) apiResponseType() string {
+	return fmt.Sprintf("ApiResponse<%s, %s>", p.successBodyType(), p.errorBodyType())
+}
+
+// renderResponseResult hands the interpreted body to the request's result,
+// with statusVariable holding the response's status code. Whether a default
+// response succeeded isn't known until the status code is, so it's decided
+// at runtime.
+func (p Path) renderResponseResult(code, statusVariable, indent string) string {
+	if p.responses[code] == nil {
+		return ""
+	}
+	body := p.respVariableName(code)
+	if code == "default" {
+		successBodyType := p.successBodyType()
+		if successBodyType != "object" && successBodyType != p.responses[code].VariableType() {
+			// Success can't hold the default body, so an undocumented 2XX is
+			// only found on the request's own field
+			return fmt.Sprintf(
+				"\n%sif (%s < 200 || %s >= 300) {\n%s\tResponse.Error = %s;\n%s}",
+				indent, statusVariable, statusVariable, indent, body, indent,
+			)
+		}
+		return fmt.Sprintf(
+			"\n%sif (%s >= 200 && %s < 300) {\n%s\tResponse.Success = %s;\n%s} else {\n%s\tResponse.Error = %s;\n%s}",
+			indent, statusVariable, statusVariable, indent, body, indent, indent, body, indent,
+		)
+	}
+	if isSuccessCode(code) {
+		return fmt.Sprintf("\n%sResponse.Success = %s;", indent, body)
+	}
+	return fmt.Sprintf("\n%sResponse.Error = %s;", indent, body)
+}
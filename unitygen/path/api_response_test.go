@@ -0,0 +1,91 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ApiResponseTypesComeFromResponseBodies(t *testing.T) {
+	tests := map[string]struct {
+		responses map[string]path.Response
+		expected  string
+	}{
+		"no responses": {
+			responses: nil,
+			expected:  "ApiResponse<object, object>",
+		},
+		"shared error type": {
+			responses: map[string]path.Response{
+				"200":     path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/user")),
+				"404":     path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/error")),
+				"default": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/error")),
+			},
+			expected: "ApiResponse<User, Error>",
+		},
+		"differing success types": {
+			responses: map[string]path.Response{
+				"200": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/user")),
+				"201": path.NewStringResponse("", true),
+				"204": nil,
+			},
+			expected: "ApiResponse<object, object>",
+		},
+		"differing error types": {
+			responses: map[string]path.Response{
+				"200": path.NewStringResponse("", true),
+				"4XX": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/error")),
+				"5XX": path.NewFileResponse(""),
+			},
+			expected: "ApiResponse<string, object>",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			route := path.NewPath("/api/v1/users", "GetUser", http.MethodGet, nil, nil, tc.responses, nil, path.PathOptions{})
+			code := route.UnityWebRequest(false)
+			assert.Contains(t, code, "\tpublic "+tc.expected+" Response { get; private set; }\n")
+			assert.Contains(t, code, "\t\tResponse = new "+tc.expected+"(req, Time.realtimeSinceStartup - sentAt);\n")
+		})
+	}
+}
+
+func Test_ApiResponseDetectsNetworkErrorsForEitherUnityVersion(t *testing.T) {
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, path.ApiResponseClasses, `#if UNITY_2020_2_OR_NEWER
+		var networkFailed = req.result == UnityWebRequest.Result.ConnectionError || req.result == UnityWebRequest.Result.DataProcessingError;
+		this.IsHttpError = req.result == UnityWebRequest.Result.ProtocolError;
+#else
+		var networkFailed = req.isNetworkError;
+		this.IsHttpError = req.isHttpError;
+#endif`)
+}
+
+func Test_ApiResponseDetectsTimeoutsFromHowLongTheRequestTook(t *testing.T) {
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, path.ApiResponseClasses, "this.TimedOut = req.timeout > 0 && elapsed >= req.timeout;")
+	assert.NotContains(t, path.ApiResponseClasses, "req.error ==")
+}
+
+func Test_NetworkErrorsSkipInterpretingTheBody(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/users", "GetUser", http.MethodGet, nil, nil, map[string]path.Response{
+		"200": path.NewStringResponse("", true),
+	}, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `	public void Interpret(UnityWebRequest req) {
+		success = default(string);
+		Response = new ApiResponse<string, object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {`)
+}
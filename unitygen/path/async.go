@@ -0,0 +1,191 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// AsyncFlavor is which kind of awaitable the async version of each service
+// function returns
+type AsyncFlavor string
+
+const (
+	// NoAsync only generates the coroutine based API
+	NoAsync AsyncFlavor = ""
+
+	// TaskAsync returns System.Threading.Tasks.Task
+	TaskAsync AsyncFlavor = "task"
+
+	// UniTaskAsync returns Cysharp.Threading.Tasks.UniTask
+	UniTaskAsync AsyncFlavor = "unitask"
+
+	// AwaitableAsync returns Unity 2023's UnityEngine.Awaitable
+	AwaitableAsync AsyncFlavor = "awaitable"
+)
+
+// AsyncFlavors is every supported async flavor
+var AsyncFlavors = []AsyncFlavor{
+	TaskAsync,
+	UniTaskAsync,
+	AwaitableAsync,
+}
+
+// ParseAsyncFlavor interprets the name of an async flavor, where "none" (or
+// nothing) means no async API
+func ParseAsyncFlavor(name string) (AsyncFlavor, error) {
+	name = strings.ToLower(name)
+	if name == "" || name == "none" {
+		return NoAsync, nil
+	}
+	for _, flavor := range AsyncFlavors {
+		if string(flavor) == name {
+			return flavor, nil
+		}
+	}
+	return NoAsync, fmt.Errorf("unknown async flavor %q", name)
+}
+
+// ReturnType wraps the type an async function results in
+func (a AsyncFlavor) ReturnType(result string) string {
+	switch a {
+	case UniTaskAsync:
+		return fmt.Sprintf("Cysharp.Threading.Tasks.UniTask<%s>", result)
+	case AwaitableAsync:
+		return fmt.Sprintf("Awaitable<%s>", result)
+	}
+	return fmt.Sprintf("System.Threading.Tasks.Task<%s>", result)
+}
+
+// nextFrame is C# that waits until the next frame
+func (a AsyncFlavor) nextFrame() string {
+	switch a {
+	case UniTaskAsync:
+		return "await Cysharp.Threading.Tasks.UniTask.Yield();"
+	case AwaitableAsync:
+		return "await Awaitable.NextFrameAsync();"
+	}
+	return "await System.Threading.Tasks.Task.Yield();"
+}
+
+// RunnerClass generates the extension method that drives a request's Run
+// coroutine to completion without needing a MonoBehaviour
+func (a AsyncFlavor) RunnerClass() string {
+	if a == NoAsync {
+		return ""
+	}
+
+	return fmt.Sprintf(`// WebRequestTasks lets requests be awaited instead of run as coroutines. The
+// request's Run coroutine is stepped through each frame, so authorization and
+// interpreting the response work the same either way.
+public static class WebRequestTasks {
+
+	// RunAsync hands the request back once it's done without disposing of it,
+	// so the awaiting code can keep reading from it and has to dispose of it
+	// itself. It's only disposed here when it never makes it back.
+	public static async %s RunAsync<T>(this T request, System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken)) where T : IWebRequest {
+		request.DisposeOnComplete = false;
+		var running = new System.Collections.Generic.Stack<IEnumerator>();
+		running.Push(request.Run());
+		try {
+			while (running.Count > 0) {
+				ThrowIfCancelled(request, running, cancellationToken);
+
+				var current = running.Peek();
+				if (current.MoveNext() == false) {
+					running.Pop();
+					continue;
+				}
+
+				var nested = current.Current as IEnumerator;
+				if (nested != null) {
+					running.Push(nested);
+					continue;
+				}
+
+				// Anything else, including WaitForSeconds and Coroutines started
+				// elsewhere, can't be waited on outside of a MonoBehaviour and
+				// only holds the request up for a frame
+				var operation = current.Current as AsyncOperation;
+				if (operation == null) {
+					%s
+					continue;
+				}
+
+				while (operation.isDone == false) {
+					ThrowIfCancelled(request, running, cancellationToken);
+					%s
+				}
+			}
+		} catch {
+			request.Dispose();
+			throw;
+		}
+		return request;
+	}
+
+	private static void ThrowIfCancelled(IWebRequest request, System.Collections.Generic.Stack<IEnumerator> running, System.Threading.CancellationToken cancellationToken) {
+		if (cancellationToken.IsCancellationRequested) {
+			request.Abort();
+
+			// Lets Run mark the result as cancelled and clean up after itself
+			while (running.Count > 0) {
+				var disposable = running.Pop() as System.IDisposable;
+				if (disposable != null) {
+					disposable.Dispose();
+				}
+			}
+			cancellationToken.ThrowIfCancellationRequested();
+		}
+	}
+}`, a.ReturnType("T"), a.nextFrame(), a.nextFrame())
+}
+
+// AsyncServiceFunction generates awaitable versions of the service function
+func (p Path) AsyncServiceFunction(flavor AsyncFlavor) string {
+	return p.asyncServiceFunction(flavor, "")
+}
+
+// asyncServiceSignatures declare the awaitable versions of the service
+// function, matching the overloads the service function has
+func (p Path) asyncServiceSignatures(flavor AsyncFlavor, nested string) []string {
+	const cancellationParam = "System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken)"
+	returnType := flavor.ReturnType(p.qualify(nested, p.unityWebReqPathName()))
+	name := convention.ClassName(p.operationID)
+
+	if len(p.parameters) == 0 {
+		return []string{fmt.Sprintf("%s %sAsync(%s)", returnType, name, cancellationParam)}
+	}
+	return []string{
+		fmt.Sprintf("%s %sAsync(%s requestParams, %s)", returnType, name, p.qualify(nested, p.requestParamClassName()), cancellationParam),
+		fmt.Sprintf("%s %sAsync(%s, %s)", returnType, name, p.serviceFunctionParameters(), cancellationParam),
+	}
+}
+
+func (p Path) asyncServiceFunction(flavor AsyncFlavor, nested string) string {
+	if flavor == NoAsync {
+		return ""
+	}
+
+	name := convention.ClassName(p.operationID)
+	signatures := p.asyncServiceSignatures(flavor, nested)
+
+	builder := strings.Builder{}
+	if len(p.parameters) == 0 {
+		fmt.Fprintf(&builder, "public async %s\n{\n", signatures[0])
+		fmt.Fprintf(&builder, "\treturn await %s().RunAsync(cancellationToken);\n}", name)
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "public async %s\n{\n", signatures[0])
+	fmt.Fprintf(&builder, "\treturn await %s(requestParams).RunAsync(cancellationToken);\n}\n\n", name)
+
+	arguments := make([]string, len(p.parameters))
+	for i, param := range p.parameters {
+		arguments[i] = convention.CamelCase(param.name)
+	}
+	fmt.Fprintf(&builder, "public async %s\n{\n", signatures[1])
+	fmt.Fprintf(&builder, "\treturn await %s(%s).RunAsync(cancellationToken);\n}", name, strings.Join(arguments, ", "))
+	return builder.String()
+}
@@ -0,0 +1,114 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AsyncServiceFunctionWithoutParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.AsyncServiceFunction(path.TaskAsync)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public async System.Threading.Tasks.Task<PingUnityWebRequest> PingAsync(System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken))
+{
+	return await Ping().RunAsync(cancellationToken);
+}`, code)
+}
+
+func Test_AsyncServiceFunctionWithParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users/{userId}",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
+			path.NewParameter(path.QueryParameterLocation, "include_meta", false, property.NewBoolean("include_meta")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.AsyncServiceFunction(path.UniTaskAsync)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public async Cysharp.Threading.Tasks.UniTask<GetUserUnityWebRequest> GetUserAsync(GetUserRequestParams requestParams, System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken))
+{
+	return await GetUser(requestParams).RunAsync(cancellationToken);
+}
+
+public async Cysharp.Threading.Tasks.UniTask<GetUserUnityWebRequest> GetUserAsync(string userId, bool includeMeta, System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken))
+{
+	return await GetUser(userId, includeMeta).RunAsync(cancellationToken);
+}`, code)
+}
+
+func Test_AsyncServiceFunctionNotGeneratedWithoutFlavor(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.AsyncServiceFunction(path.NoAsync)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "", code)
+}
+
+func Test_AsyncRunnerClass(t *testing.T) {
+	tests := map[string]struct {
+		flavor     path.AsyncFlavor
+		returnType string
+		nextFrame  string
+	}{
+		"task":      {flavor: path.TaskAsync, returnType: "System.Threading.Tasks.Task<T>", nextFrame: "await System.Threading.Tasks.Task.Yield();"},
+		"unitask":   {flavor: path.UniTaskAsync, returnType: "Cysharp.Threading.Tasks.UniTask<T>", nextFrame: "await Cysharp.Threading.Tasks.UniTask.Yield();"},
+		"awaitable": {flavor: path.AwaitableAsync, returnType: "Awaitable<T>", nextFrame: "await Awaitable.NextFrameAsync();"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			code := tc.flavor.RunnerClass()
+			assert.Contains(t, code, "public static async "+tc.returnType+" RunAsync<T>(this T request")
+			assert.Contains(t, code, tc.nextFrame)
+		})
+	}
+	assert.Equal(t, "", path.NoAsync.RunnerClass())
+}
+
+func Test_ParseAsyncFlavor(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    path.AsyncFlavor
+		wantErr string
+	}{
+		"empty":     {input: "", want: path.NoAsync},
+		"none":      {input: "none", want: path.NoAsync},
+		"task":      {input: "Task", want: path.TaskAsync},
+		"unitask":   {input: "unitask", want: path.UniTaskAsync},
+		"awaitable": {input: "awaitable", want: path.AwaitableAsync},
+		"unknown":   {input: "promise", wantErr: `unknown async flavor "promise"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			flavor, err := path.ParseAsyncFlavor(tc.input)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, flavor)
+		})
+	}
+}
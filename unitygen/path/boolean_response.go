@@ -0,0 +1,28 @@
+package path
+
+import "fmt"
+
+// BooleanResponse is a type of response that expects true or false.
+type BooleanResponse struct {
+	description string
+}
+
+// NewBooleanResponse creates a new boolean response
+func NewBooleanResponse(description string) BooleanResponse {
+	return BooleanResponse{
+		description: description,
+	}
+}
+
+// Description of the response
+func (resp BooleanResponse) Description() string {
+	return resp.description
+}
+
+func (resp BooleanResponse) Interpret(variableName string, downloadHandlerVariableName string) string {
+	return fmt.Sprintf("%s = bool.Parse(%s);", variableName, plainTextBody(downloadHandlerVariableName))
+}
+
+func (resp BooleanResponse) VariableType() string {
+	return "bool"
+}
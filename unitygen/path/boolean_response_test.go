@@ -0,0 +1,23 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BooleanResponse(t *testing.T) {
+	// ARRANGE ================================================================
+	desciption := "A bunch of cool cats"
+	defResp := path.NewBooleanResponse(desciption)
+
+	// ACT ====================================================================
+	desc := defResp.Description()
+	interpret := defResp.Interpret("somethin", "download")
+
+	// ASSERT =================================================================
+	assert.Equal(t, desciption, desc)
+	assert.Equal(t, "somethin = bool.Parse(download.text.Trim());", interpret)
+	assert.Equal(t, "bool", defResp.VariableType())
+}
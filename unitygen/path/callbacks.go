@@ -0,0 +1,192 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// CallbackClasses is the C# the callback based service functions depend on
+const CallbackClasses = `// ApiError describes why a request didn't succeed
+public class ApiError {
+
+	// Status code of the response, 0 when the server was never reached
+	public long StatusCode { get; private set; }
+
+	// What went wrong, if Unity or the generated code knows
+	public string Message { get; private set; }
+
+	// Body of the response, if there was one
+	public string Body { get; private set; }
+
+	// The request that failed, disposed of once the callbacks have been called
+	// unless the request's DisposeOnComplete is turned off
+	public UnityWebRequest Request { get; private set; }
+
+	// Result of the request, for everything known about what went wrong
+	public IApiResponse Response { get; private set; }
+
+	public ApiError(IWebRequest request) {
+		this.Request = request.UnderlyingRequest;
+		this.Response = request.Response;
+		this.StatusCode = request.Response.StatusCode;
+		this.Body = request.Response.Text;
+		if (request.Response.Cancelled) {
+			this.Message = "the request was cancelled";
+		} else if (request.Response.NetworkError != null) {
+			this.Message = request.Response.NetworkError;
+		} else if (request.Response.ParseException != null) {
+			this.Message = "unable to interpret the response: " + request.Response.ParseException.Message;
+		} else {
+			this.Message = request.UnderlyingRequest.error;
+		}
+	}
+
+	public override string ToString() {
+		return string.Format("request failed ({0}): {1}", StatusCode, string.IsNullOrEmpty(Message) ? Body : Message);
+	}
+}
+
+// WebRequestRunner is a hidden MonoBehaviour that runs requests for callers
+// who don't want to start coroutines themselves
+public class WebRequestRunner : MonoBehaviour {
+
+	private static WebRequestRunner instance;
+
+	private static WebRequestRunner Instance {
+		get {
+			if (instance == null) {
+				var runnerObject = new GameObject("WebRequestRunner");
+				runnerObject.hideFlags = HideFlags.HideAndDontSave;
+				if (Application.isPlaying) {
+					DontDestroyOnLoad(runnerObject);
+				}
+				instance = runnerObject.AddComponent<WebRequestRunner>();
+			}
+			return instance;
+		}
+	}
+
+	// Run starts the request, reporting its progress every frame and calling
+	// onDone once it's finished
+	public static T Run<T>(T request, System.Action<T> onDone, System.Action<float> onProgress) where T : IWebRequest {
+		Instance.StartCoroutine(Instance.RunRequest(request, onDone, onProgress));
+		return request;
+	}
+
+	private IEnumerator RunRequest<T>(T request, System.Action<T> onDone, System.Action<float> onProgress) where T : IWebRequest {
+		// The callbacks still need the request once it's done running
+		var dispose = request.DisposeOnComplete;
+		request.DisposeOnComplete = false;
+
+		if (onProgress == null) {
+			yield return StartCoroutine(request.Run());
+		} else {
+			var running = true;
+			StartCoroutine(RunToCompletion(request.Run(), () => running = false));
+			while (running) {
+				onProgress(request.UnderlyingRequest.downloadProgress);
+				yield return null;
+			}
+			onProgress(1f);
+		}
+
+		try {
+			if (onDone != null) {
+				onDone(request);
+			}
+		} finally {
+			if (dispose) {
+				request.Dispose();
+			}
+		}
+	}
+
+	private IEnumerator RunToCompletion(IEnumerator routine, System.Action onDone) {
+		yield return StartCoroutine(routine);
+		onDone();
+	}
+}`
+
+// hasSuccessBody is whether or not any 2XX response comes with a body, in
+// which case success callbacks are handed the response's Success, typed as
+// whatever the 2XX bodies have in common
+func (p Path) hasSuccessBody() bool {
+	for code, resp := range p.responses {
+		if isSuccessCode(code) && resp != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// CallbackServiceFunction generates versions of the service function that are
+// run by a hidden MonoBehaviour and report back through callbacks
+func (p Path) CallbackServiceFunction() string {
+	return p.callbackServiceFunction("WebRequestRunner", "")
+}
+
+// callbackServiceSignatures declare the callback based versions of the
+// service function, matching the overloads the service function has
+func (p Path) callbackServiceSignatures(nested string) []string {
+	const callbackParams = "System.Action<ApiError> onError, System.Action<float> onProgress = null"
+	returnType := p.qualify(nested, p.unityWebReqPathName())
+	name := convention.ClassName(p.operationID)
+
+	successParam := "System.Action onSuccess"
+	if p.hasSuccessBody() {
+		successParam = fmt.Sprintf("System.Action<%s> onSuccess", p.successBodyType())
+	}
+
+	if len(p.parameters) == 0 {
+		return []string{fmt.Sprintf("%s %s(%s, %s)", returnType, name, successParam, callbackParams)}
+	}
+	return []string{
+		fmt.Sprintf("%s %s(%s requestParams, %s, %s)", returnType, name, p.qualify(nested, p.requestParamClassName()), successParam, callbackParams),
+		fmt.Sprintf("%s %s(%s, %s, %s)", returnType, name, p.serviceFunctionParameters(), successParam, callbackParams),
+	}
+}
+
+// callbackServiceFunction generates the callback based versions of the
+// service function, with runner being the class that runs the request
+func (p Path) callbackServiceFunction(runner, nested string) string {
+	name := convention.ClassName(p.operationID)
+	signatures := p.callbackServiceSignatures(nested)
+
+	successCall := "onSuccess()"
+	if p.hasSuccessBody() {
+		successCall = "onSuccess(req.Response.Success)"
+	}
+
+	body := func(request string) string {
+		builder := strings.Builder{}
+		fmt.Fprintf(&builder, "\treturn %s.Run(%s, req => {\n", runner, request)
+		builder.WriteString("\t\tif (req.Response.IsSuccess) {\n")
+		fmt.Fprintf(&builder, "\t\t\tif (onSuccess != null) {\n\t\t\t\t%s;\n\t\t\t}\n", successCall)
+		builder.WriteString("\t\t} else if (onError != null) {\n")
+		builder.WriteString("\t\t\tonError(new ApiError(req));\n")
+		builder.WriteString("\t\t}\n")
+		builder.WriteString("\t}, onProgress);\n}")
+		return builder.String()
+	}
+
+	builder := strings.Builder{}
+	if len(p.parameters) == 0 {
+		fmt.Fprintf(&builder, "public %s\n{\n", signatures[0])
+		builder.WriteString(body(name + "()"))
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "public %s\n{\n", signatures[0])
+	builder.WriteString(body(name + "(requestParams)"))
+	builder.WriteString("\n\n")
+
+	arguments := make([]string, len(p.parameters))
+	for i, param := range p.parameters {
+		arguments[i] = convention.CamelCase(param.name)
+	}
+	fmt.Fprintf(&builder, "public %s\n{\n", signatures[1])
+	builder.WriteString(body(fmt.Sprintf("%s(%s)", name, strings.Join(arguments, ", "))))
+	return builder.String()
+}
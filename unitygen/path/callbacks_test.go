@@ -0,0 +1,106 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CallbackServiceFunctionWithoutResponseBody(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.CallbackServiceFunction()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public PingUnityWebRequest Ping(System.Action onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null)
+{
+	return WebRequestRunner.Run(Ping(), req => {
+		if (req.Response.IsSuccess) {
+			if (onSuccess != null) {
+				onSuccess();
+			}
+		} else if (onError != null) {
+			onError(new ApiError(req));
+		}
+	}, onProgress);
+}`, code)
+}
+
+func Test_CallbackServiceFunctionWithParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users/{userId}",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200":     path.NewDefinitionResponse("A successful response.", model.NewDefinitionReference("#/definitions/v1UserResponse")),
+			"default": path.NewDefinitionResponse("An unexpected error response", model.NewDefinitionReference("#/definitions/runtimeError")),
+		},
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.CallbackServiceFunction()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public GetUserUnityWebRequest GetUser(GetUserRequestParams requestParams, System.Action<V1UserResponse> onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null)
+{
+	return WebRequestRunner.Run(GetUser(requestParams), req => {
+		if (req.Response.IsSuccess) {
+			if (onSuccess != null) {
+				onSuccess(req.Response.Success);
+			}
+		} else if (onError != null) {
+			onError(new ApiError(req));
+		}
+	}, onProgress);
+}
+
+public GetUserUnityWebRequest GetUser(string userId, System.Action<V1UserResponse> onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null)
+{
+	return WebRequestRunner.Run(GetUser(userId), req => {
+		if (req.Response.IsSuccess) {
+			if (onSuccess != null) {
+				onSuccess(req.Response.Success);
+			}
+		} else if (onError != null) {
+			onError(new ApiError(req));
+		}
+	}, onProgress);
+}`, code)
+}
+
+func Test_CallbackServiceFunctionWithDifferingSuccessBodies(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/recordings",
+		"CreateRecording",
+		http.MethodPost,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200": path.NewDefinitionResponse("An existing recording.", model.NewDefinitionReference("#/definitions/Recording")),
+			"201": path.NewDefinitionResponse("A new recording.", model.NewDefinitionReference("#/definitions/RecordingCreated")),
+		},
+		nil,
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.CallbackServiceFunction()
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "CreateRecording(System.Action<object> onSuccess, ")
+	assert.Contains(t, code, "onSuccess(req.Response.Success);")
+}
@@ -0,0 +1,173 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+)
+
+// CallerName is what the MonoBehaviour that sends the request from the
+// Inspector is called
+func (p Path) CallerName() string {
+	return fmt.Sprintf("%sCaller", convention.ClassName(p.operationID))
+}
+
+// serializedField writes out a field Unity shows in the Inspector, along with
+// a property for setting it from code
+func serializedField(builder *strings.Builder, description, variableType, name string) {
+	privateVarName := convention.CamelCase(name)
+	fmt.Fprintf(builder, "\t[SerializeField]\n\tprivate %s %s;\n\n", variableType, privateVarName)
+	if description != "" {
+		fmt.Fprintf(builder, "\t// %s\n", description)
+	}
+	fmt.Fprintf(
+		builder,
+		"\tpublic %s %s { get { return %s; } set { %s = value; } }\n\n",
+		variableType,
+		convention.TitleCase(name),
+		privateVarName,
+		privateVarName,
+	)
+}
+
+// serializedEvent writes out a UnityEvent listeners can be added to from the
+// Inspector or from code
+func serializedEvent(builder *strings.Builder, description, eventType, name string) {
+	privateVarName := convention.CamelCase(name)
+	fmt.Fprintf(builder, "\t[SerializeField]\n\tprivate %s %s = new %s();\n\n", eventType, privateVarName, eventType)
+	fmt.Fprintf(builder, "\t// %s\n", description)
+	fmt.Fprintf(builder, "\tpublic %s %s { get { return %s; } }\n\n", eventType, convention.TitleCase(name), privateVarName)
+}
+
+// callerParamField is the caller's field holding the parameter
+func callerParamField(param Parameter) string {
+	return convention.CamelCase(param.name) + "Param"
+}
+
+// callerIncludeField is the caller's field for whether or not the optional
+// parameter is sent
+func callerIncludeField(param Parameter) string {
+	return "include" + convention.TitleCase(param.name) + "Param"
+}
+
+// editableInInspector is whether or not Unity can serialize the property's
+// type, letting it be set in the Inspector. Definitions only have private
+// setters, and Unity can't serialize DateTimes or nested arrays.
+func editableInInspector(prop model.Property) bool {
+	switch v := prop.(type) {
+	case property.String:
+		return v.ToVariableType() == "string"
+	case property.Integer, property.Number, property.Boolean:
+		return true
+	case property.Array:
+		if _, nested := v.Property().(property.Array); nested {
+			return false
+		}
+		return editableInInspector(v.Property())
+	}
+	return false
+}
+
+// callerParamAssignment sets the parameter on the request params from the
+// caller's field. Parameters the Inspector can't edit are written as JSON,
+// which fails the call when it can't be read.
+func callerParamAssignment(builder *strings.Builder, param Parameter, indent string) {
+	propertyName := convention.TitleCase(param.name)
+	if editableInInspector(param.parameterType) {
+		fmt.Fprintf(builder, "%srequestParams.%s = %s;\n", indent, propertyName, callerParamField(param))
+		return
+	}
+	fmt.Fprintf(builder, "%stry {\n", indent)
+	fmt.Fprintf(builder, "%s\trequestParams.%s = JsonConvert.DeserializeObject<%s>(%s);\n", indent, propertyName, param.parameterType.ToVariableType(), callerParamField(param))
+	fmt.Fprintf(builder, "%s} catch (JsonException e) {\n", indent)
+	fmt.Fprintf(builder, "%s\tonFailure.Invoke(string.Format(\"{0} has invalid JSON for %s: {1}\", this.name, e.Message));\n", indent, propertyName)
+	fmt.Fprintf(builder, "%s\treturn;\n%s}\n", indent, indent)
+}
+
+// Caller is a MonoBehaviour that sends the request when Call is invoked, with
+// its parameters and config set in the Inspector, reporting back through
+// UnityEvents. Service is the class name of the service the path belongs to,
+// and configName the scriptable object config requests are sent with.
+func (p Path) Caller(service, configName string) string {
+	name := convention.ClassName(p.operationID)
+	configClassName := convention.TitleCase(configName)
+
+	successEvent := "UnityEngine.Events.UnityEvent"
+	successCall := "onSuccess.Invoke()"
+	if p.hasSuccessBody() {
+		successEvent = fmt.Sprintf("UnityEngine.Events.UnityEvent<%s>", p.successBodyType())
+		successCall = "onSuccess.Invoke(req.Response.Success)"
+	}
+
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "// %s sends %s when Call is invoked, for wiring the request up\n", p.CallerName(), name)
+	builder.WriteString("// in the Inspector\n")
+	fmt.Fprintf(&builder, "public class %s : MonoBehaviour {\n\n", p.CallerName())
+
+	// Older versions of Unity can't serialize generic UnityEvents
+	fmt.Fprintf(&builder, "\t[System.Serializable]\n\tpublic class SucceededEvent : %s { }\n\n", successEvent)
+	builder.WriteString("\t[System.Serializable]\n\tpublic class FailedEvent : UnityEngine.Events.UnityEvent<string> { }\n\n")
+
+	serializedField(&builder, "Config the request is sent with", configClassName, "config")
+
+	// Parameters are suffixed so they can't clash with the caller's own
+	// members, or hide the ones it inherits like name and enabled
+	for _, param := range p.parameters {
+		if param.required == false && param.location != PathParameterLocation {
+			serializedField(&builder, fmt.Sprintf("Whether or not %s is sent with the request", convention.TitleCase(param.name)), "bool", callerIncludeField(param))
+		}
+		if editableInInspector(param.parameterType) {
+			serializedField(&builder, "", param.parameterType.ToVariableType(), callerParamField(param))
+		} else {
+			serializedField(&builder, fmt.Sprintf("%s as JSON, since the Inspector can't edit %s", convention.TitleCase(param.name), param.parameterType.ToVariableType()), "string", callerParamField(param))
+		}
+	}
+
+	serializedEvent(&builder, "Invoked with the response once the request succeeds", "SucceededEvent", "onSuccess")
+	serializedEvent(&builder, "Invoked with what went wrong when the request doesn't succeed", "FailedEvent", "onFailure")
+
+	fmt.Fprintf(&builder, "\tprivate %s running;\n\n", p.qualify(service, p.unityWebReqPathName()))
+
+	builder.WriteString("\t// Call sends the request with the parameters set on the caller\n")
+	builder.WriteString("\tpublic void Call() {\n")
+	builder.WriteString("\t\tif (config == null) {\n")
+	fmt.Fprintf(&builder, "\t\t\tonFailure.Invoke(string.Format(\"{0} has no config to send %s with\", this.name));\n", name)
+	builder.WriteString("\t\t\treturn;\n\t\t}\n\n")
+
+	request := fmt.Sprintf("new %s(config).%s()", service, name)
+	if len(p.parameters) > 0 {
+		fmt.Fprintf(&builder, "\t\tvar requestParams = new %s();\n", p.qualify(service, p.requestParamClassName()))
+		for _, param := range p.parameters {
+			if param.required == false && param.location != PathParameterLocation {
+				fmt.Fprintf(&builder, "\t\tif (%s) {\n", callerIncludeField(param))
+				callerParamAssignment(&builder, param, "\t\t\t")
+				builder.WriteString("\t\t}\n")
+			} else {
+				callerParamAssignment(&builder, param, "\t\t")
+			}
+		}
+		builder.WriteString("\n")
+		request = fmt.Sprintf("new %s(config).%s(requestParams)", service, name)
+	}
+
+	fmt.Fprintf(&builder, "\t\trunning = WebRequestRunner.Run(%s, req => {\n", request)
+	builder.WriteString("\t\t\tif (running == req) {\n\t\t\t\trunning = null;\n\t\t\t}\n\n")
+	builder.WriteString("\t\t\t// Nothing is listening anymore once the caller is destroyed\n")
+	builder.WriteString("\t\t\tif (this == null) {\n\t\t\t\treturn;\n\t\t\t}\n\n")
+	builder.WriteString("\t\t\tif (req.Response.IsSuccess) {\n")
+	fmt.Fprintf(&builder, "\t\t\t\t%s;\n", successCall)
+	builder.WriteString("\t\t\t} else {\n")
+	builder.WriteString("\t\t\t\tonFailure.Invoke(new ApiError(req).ToString());\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t}, null);\n")
+	builder.WriteString("\t}\n\n")
+
+	builder.WriteString("\tprivate void OnDestroy() {\n")
+	builder.WriteString("\t\tif (running != null) {\n\t\t\trunning.Abort();\n\t\t}\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,215 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CallerWithoutParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.Caller("HealthService", "ServiceConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "PingCaller", route.CallerName())
+	assert.Equal(t, `// PingCaller sends Ping when Call is invoked, for wiring the request up
+// in the Inspector
+public class PingCaller : MonoBehaviour {
+
+	[System.Serializable]
+	public class SucceededEvent : UnityEngine.Events.UnityEvent { }
+
+	[System.Serializable]
+	public class FailedEvent : UnityEngine.Events.UnityEvent<string> { }
+
+	[SerializeField]
+	private ServiceConfig config;
+
+	// Config the request is sent with
+	public ServiceConfig Config { get { return config; } set { config = value; } }
+
+	[SerializeField]
+	private SucceededEvent onSuccess = new SucceededEvent();
+
+	// Invoked with the response once the request succeeds
+	public SucceededEvent OnSuccess { get { return onSuccess; } }
+
+	[SerializeField]
+	private FailedEvent onFailure = new FailedEvent();
+
+	// Invoked with what went wrong when the request doesn't succeed
+	public FailedEvent OnFailure { get { return onFailure; } }
+
+	private HealthService.PingUnityWebRequest running;
+
+	// Call sends the request with the parameters set on the caller
+	public void Call() {
+		if (config == null) {
+			onFailure.Invoke(string.Format("{0} has no config to send Ping with", this.name));
+			return;
+		}
+
+		running = WebRequestRunner.Run(new HealthService(config).Ping(), req => {
+			if (running == req) {
+				running = null;
+			}
+
+			// Nothing is listening anymore once the caller is destroyed
+			if (this == null) {
+				return;
+			}
+
+			if (req.Response.IsSuccess) {
+				onSuccess.Invoke();
+			} else {
+				onFailure.Invoke(new ApiError(req).ToString());
+			}
+		}, null);
+	}
+
+	private void OnDestroy() {
+		if (running != null) {
+			running.Abort();
+		}
+	}
+}`, code)
+}
+
+func Test_CallerSerializesParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/leaderboards/{leaderboardId}",
+		"GetLeaderboard",
+		http.MethodGet,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200": path.NewDefinitionResponse("A successful response.", model.NewDefinitionReference("#/definitions/Leaderboard")),
+		},
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "leaderboardId", true, property.NewString("leaderboardId", "")),
+			path.NewParameter(path.QueryParameterLocation, "limit", false, property.NewInteger("limit", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.Caller("LeaderboardService", "RecoludeConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "public class SucceededEvent : UnityEngine.Events.UnityEvent<Leaderboard> { }")
+	assert.Contains(t, code, `	[SerializeField]
+	private RecoludeConfig config;`)
+	assert.Contains(t, code, `	[SerializeField]
+	private string leaderboardIdParam;
+
+	public string LeaderboardIdParam { get { return leaderboardIdParam; } set { leaderboardIdParam = value; } }
+
+	[SerializeField]
+	private bool includeLimitParam;
+
+	// Whether or not Limit is sent with the request
+	public bool IncludeLimitParam { get { return includeLimitParam; } set { includeLimitParam = value; } }
+
+	[SerializeField]
+	private int limitParam;
+`)
+	assert.Contains(t, code, `		var requestParams = new LeaderboardService.GetLeaderboardRequestParams();
+		requestParams.LeaderboardId = leaderboardIdParam;
+		if (includeLimitParam) {
+			requestParams.Limit = limitParam;
+		}
+
+		running = WebRequestRunner.Run(new LeaderboardService(config).GetLeaderboard(requestParams), req => {`)
+	assert.Contains(t, code, "onSuccess.Invoke(req.Response.Success);")
+}
+
+func Test_CallerParametersDontClashWithItsMembers(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/configs/{name}",
+		"UpdateConfig",
+		http.MethodPut,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "name", true, property.NewString("name", "")),
+			path.NewParameter(path.BodyParameterLocation, "config", true, property.NewString("config", "")),
+			path.NewParameter(path.QueryParameterLocation, "enabled", false, property.NewBoolean("enabled")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.Caller("ConfigService", "RecoludeConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\tprivate RecoludeConfig config;\n")
+	assert.Contains(t, code, "\tprivate string nameParam;\n")
+	assert.Contains(t, code, "\tprivate string configParam;\n")
+	assert.Contains(t, code, "\tprivate bool includeEnabledParam;\n")
+	assert.Contains(t, code, "\tprivate bool enabledParam;\n")
+	assert.Contains(t, code, `		requestParams.Name = nameParam;
+		requestParams.Config = configParam;
+		if (includeEnabledParam) {
+			requestParams.Enabled = enabledParam;
+		}`)
+	assert.Contains(t, code, "this.name));")
+}
+
+func Test_CallerEditsDefinitionParametersAsJSON(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/pets",
+		"AddPet",
+		http.MethodPost,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.BodyParameterLocation, "body", true, property.NewDefinitionReference("body", model.NewDefinitionReference("#/definitions/Pet"))),
+			path.NewParameter(path.QueryParameterLocation, "tags", false, property.NewArray("tags", property.NewString("tags", ""))),
+			path.NewParameter(path.QueryParameterLocation, "bornAfter", false, property.NewString("bornAfter", "date-time")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.Caller("PetService", "RecoludeConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `	[SerializeField]
+	private string bodyParam;
+
+	// Body as JSON, since the Inspector can't edit Pet
+	public string BodyParam { get { return bodyParam; } set { bodyParam = value; } }
+`)
+	assert.Contains(t, code, "\tprivate string[] tagsParam;\n")
+	assert.Contains(t, code, "\tprivate string bornAfterParam;\n")
+	assert.Contains(t, code, `		try {
+			requestParams.Body = JsonConvert.DeserializeObject<Pet>(bodyParam);
+		} catch (JsonException e) {
+			onFailure.Invoke(string.Format("{0} has invalid JSON for Body: {1}", this.name, e.Message));
+			return;
+		}
+		if (includeTagsParam) {
+			requestParams.Tags = tagsParam;
+		}
+		if (includeBornAfterParam) {
+			try {
+				requestParams.BornAfter = JsonConvert.DeserializeObject<System.DateTime>(bornAfterParam);
+			} catch (JsonException e) {
+				onFailure.Invoke(string.Format("{0} has invalid JSON for BornAfter: {1}", this.name, e.Message));
+				return;
+			}
+		}
+`)
+}
@@ -0,0 +1,201 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// FakeServiceClasses is the C# every generated fake service is built on
+const FakeServiceClasses = `// FakeServiceCall is a call made to a fake service
+public class FakeServiceCall {
+
+	// Name of the operation called
+	public string Operation { get; private set; }
+
+	// Parameters the operation was called with, null when it takes none
+	public object Parameters { get; private set; }
+
+	public FakeServiceCall(string operation, object parameters) {
+		this.Operation = operation;
+		this.Parameters = parameters;
+	}
+}
+
+// FakeService is what generated fake services are built on. Responses are
+// queued up per operation and every call made is recorded, so code using a
+// service can be tested without a network.
+public abstract class FakeService {
+
+	protected class CannedResponse {
+		public long StatusCode;
+		public object Body;
+	}
+
+	private readonly System.Collections.Generic.Dictionary<string, System.Collections.Generic.Queue<CannedResponse>> responses = new System.Collections.Generic.Dictionary<string, System.Collections.Generic.Queue<CannedResponse>>();
+
+	private readonly System.Collections.Generic.List<FakeServiceCall> calls = new System.Collections.Generic.List<FakeServiceCall>();
+
+	// Every call made to the service, in the order they were made
+	public System.Collections.Generic.IList<FakeServiceCall> Calls { get { return calls.AsReadOnly(); } }
+
+	// Respond queues up the status code and body the operation's next call is
+	// answered with. Calls with nothing queued get a 200 without a body.
+	public void Respond(string operation, long statusCode, object body = null) {
+		if (responses.ContainsKey(operation) == false) {
+			responses[operation] = new System.Collections.Generic.Queue<CannedResponse>();
+		}
+		responses[operation].Enqueue(new CannedResponse() { StatusCode = statusCode, Body = body });
+	}
+
+	// CallsTo are the calls made to the operation, in the order they were made
+	public System.Collections.Generic.List<FakeServiceCall> CallsTo(string operation) {
+		return calls.FindAll(call => call.Operation == operation);
+	}
+
+	// Reset forgets every queued response and recorded call
+	public void Reset() {
+		responses.Clear();
+		calls.Clear();
+	}
+
+	// Record notes down the call, handing back what it's answered with
+	protected CannedResponse Record(string operation, object parameters) {
+		calls.Add(new FakeServiceCall(operation, parameters));
+		if (responses.ContainsKey(operation) && responses[operation].Count > 0) {
+			return responses[operation].Dequeue();
+		}
+		return new CannedResponse() { StatusCode = 200 };
+	}
+
+	// Run finishes the request right away, since a fake has nothing to wait
+	// on, and then calls onDone
+	public static T Run<T>(T request, System.Action<T> onDone, System.Action<float> onProgress) where T : IWebRequest {
+		var dispose = request.DisposeOnComplete;
+		request.DisposeOnComplete = false;
+
+		var running = request.Run();
+		while (running.MoveNext()) {
+		}
+		if (onProgress != null) {
+			onProgress(1f);
+		}
+
+		try {
+			if (onDone != null) {
+				onDone(request);
+			}
+		} finally {
+			if (dispose) {
+				request.Dispose();
+			}
+		}
+		return request;
+	}
+}`
+
+// respondFields let the request be finished with a canned response instead of
+// being sent
+func (p Path) respondFields() string {
+	builder := strings.Builder{}
+	builder.WriteString("\tprivate bool responded;\n\n")
+	builder.WriteString("\t// Respond has Run finish with the status code and body given instead of\n")
+	builder.WriteString("\t// sending the request, which is how fake services answer requests\n")
+	fmt.Fprintf(&builder, "\tpublic %s Respond(long statusCode, object body) {\n", p.unityWebReqPathName())
+	builder.WriteString("\t\tresponded = true;\n")
+	fmt.Fprintf(&builder, "\t\tResponse = new %s(statusCode);\n", p.apiResponseType())
+	if body := p.renderRespond(); body != "" {
+		builder.WriteString("\t\ttry {\n")
+		builder.WriteString(body)
+		builder.WriteString("\t\t} catch (System.Exception e) {\n\t\t\tResponse.ParseException = e;\n\t\t}\n")
+	}
+	builder.WriteString("\t\treturn this;\n\t}\n\n")
+	return builder.String()
+}
+
+// renderRespond hands a canned body to whichever response the status code
+// belongs to, the same way interpreting a real response does
+func (p Path) renderRespond() string {
+	codes := p.orderedResponseCodes()
+	if len(codes) == 0 {
+		return ""
+	}
+
+	cast := func(code string) string {
+		if p.responses[code] == nil {
+			return "// No expected response. Do nothing!"
+		}
+		return fmt.Sprintf("%s = (%s)body;", p.respVariableName(code), p.responses[code].VariableType())
+	}
+
+	// A lone default response catches everything
+	if len(codes) == 1 && codes[0] == "default" {
+		return fmt.Sprintf("\t\t\t%s%s\n", cast(codes[0]), p.renderResponseResult(codes[0], "statusCode", "\t\t\t"))
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("\t\t\t")
+	for codeIndex, code := range codes {
+		if code == "default" {
+			fmt.Fprintf(&builder, "{\n\t\t\t\t%s%s\n\t\t\t}", cast(code), p.renderResponseResult(code, "statusCode", "\t\t\t\t"))
+		} else {
+			fmt.Fprintf(&builder, "if (%s) {\n\t\t\t\t%s%s\n\t\t\t}", p.statusCondition(code, "statusCode"), cast(code), p.renderResponseResult(code, "statusCode", "\t\t\t\t"))
+		}
+		if codeIndex < len(codes)-1 {
+			builder.WriteString(" else ")
+		}
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// ServiceSignatures declare every function the service generates for the
+// path, which is what the service's interface is made of. Types the service
+// nests are qualified with nested, the service's class name.
+func (p Path) ServiceSignatures(nested string, async AsyncFlavor, callbacks, fluent bool) []string {
+	signatures := []string{p.serviceFunctionSignature(nested)}
+	if len(p.parameters) > 0 {
+		signatures = append(signatures, p.functionOverideSignature(nested))
+	}
+	if async != NoAsync {
+		signatures = append(signatures, p.asyncServiceSignatures(async, nested)...)
+	}
+	if callbacks {
+		signatures = append(signatures, p.callbackServiceSignatures(nested)...)
+	}
+	if fluent {
+		signatures = append(signatures, p.requestBuilderSignature(nested))
+	}
+	return signatures
+}
+
+// FakeServiceFunctions implements every function the service generates for
+// the path, answering with whatever response has been queued up for the
+// operation instead of sending anything
+func (p Path) FakeServiceFunctions(nested string, async AsyncFlavor, callbacks, fluent bool) string {
+	name := convention.ClassName(p.operationID)
+	parameters := "null"
+	if len(p.parameters) > 0 {
+		parameters = "requestParams"
+	}
+
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "public %s\n{\n", p.serviceFunctionSignature(nested))
+	fmt.Fprintf(&builder, "\tvar response = Record(%q, %s);\n", name, parameters)
+	fmt.Fprintf(&builder, "\treturn new %s(new UnityWebRequest()).Respond(response.StatusCode, response.Body);\n}", p.qualify(nested, p.unityWebReqPathName()))
+
+	if len(p.parameters) > 0 {
+		fmt.Fprintf(&builder, "\n\n%s", p.functionOveride(nested))
+	}
+	if async != NoAsync {
+		fmt.Fprintf(&builder, "\n\n%s", p.asyncServiceFunction(async, nested))
+	}
+	if callbacks {
+		fmt.Fprintf(&builder, "\n\n%s", p.callbackServiceFunction("FakeService", nested))
+	}
+	if fluent {
+		fmt.Fprintf(&builder, "\n\n%s", p.requestBuilderServiceFunction(nested))
+	}
+	return builder.String()
+}
@@ -0,0 +1,136 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RespondHandsTheBodyToTheMatchingResponse(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/user")),
+			"404": nil,
+			"5XX": path.NewIntegerResponse(""),
+		},
+		nil,
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(true)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `	public GetUserUnityWebRequest Respond(long statusCode, object body) {
+		responded = true;
+		Response = new ApiResponse<User, int>(statusCode);
+		try {
+			if (statusCode == 200) {
+				success = (User)body;
+				Response.Success = success;
+			} else if (statusCode == 404) {
+				// No expected response. Do nothing!
+			} else if (statusCode >= 500 && statusCode < 600) {
+				serverError = (int)body;
+				Response.Error = serverError;
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
+		}
+		return this;
+	}
+`)
+	assert.Contains(t, code, "\t\ttry {\n\t\t\tif (responded) {\n\t\t\t\tyield break;\n\t\t\t}\n")
+}
+
+func Test_RespondWithoutResponses(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(true)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\tpublic PingUnityWebRequest Respond(long statusCode, object body) {\n\t\tresponded = true;\n\t\tResponse = new ApiResponse<object, object>(statusCode);\n\t\treturn this;\n\t}\n")
+}
+
+func Test_OnlyFakedRequestsCanBeRespondedTo(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, code, "Respond(")
+	assert.NotContains(t, code, "responded")
+}
+
+func Test_FakeServiceFunctionsRecordTheirParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users/{userId}",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.FakeServiceFunctions("UserService", path.NoAsync, false, false)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public UserService.GetUserUnityWebRequest GetUser(UserService.GetUserRequestParams requestParams)
+{
+	var response = Record("GetUser", requestParams);
+	return new UserService.GetUserUnityWebRequest(new UnityWebRequest()).Respond(response.StatusCode, response.Body);
+}
+
+public UserService.GetUserUnityWebRequest GetUser(string userId)
+{
+	return GetUser(new UserService.GetUserRequestParams() {
+		UserId=userId,
+	});
+}`, code)
+}
+
+func Test_ServiceSignaturesQualifyNestedTypes(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users/{userId}",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	signatures := route.ServiceSignatures("UserService", path.NoAsync, false, true)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, []string{
+		"UserService.GetUserUnityWebRequest GetUser(UserService.GetUserRequestParams requestParams)",
+		"UserService.GetUserUnityWebRequest GetUser(string userId)",
+		"UserService.IGetUserRequestBuilderNeedsUserId GetUserBuilder()",
+	}, signatures)
+}
@@ -0,0 +1,143 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+func (p Path) requestBuilderClassName() string {
+	return convention.ClassName(p.operationID) + "RequestBuilder"
+}
+
+func (p Path) requestBuilderInterfaceName() string {
+	return "I" + p.requestBuilderClassName()
+}
+
+func (p Path) requestBuilderStageName(param Parameter) string {
+	return fmt.Sprintf("%sNeeds%s", p.requestBuilderInterfaceName(), convention.TitleCase(param.name))
+}
+
+// requiredStages are the parameters the builder won't let a request be sent
+// without, in the order they have to be provided
+func (p Path) requiredStages() []Parameter {
+	stages := make([]Parameter, 0)
+	for _, param := range p.parameters {
+		if param.location == PathParameterLocation && param.required {
+			stages = append(stages, param)
+		}
+	}
+	return stages
+}
+
+// firstRequestBuilderStage is what a fresh builder is handed back as
+func (p Path) firstRequestBuilderStage() string {
+	stages := p.requiredStages()
+	if len(stages) == 0 {
+		return p.requestBuilderInterfaceName()
+	}
+	return p.requestBuilderStageName(stages[0])
+}
+
+func (p Path) requestBuilderSender() string {
+	if len(p.parameters) == 0 {
+		return fmt.Sprintf("System.Func<%s>", p.unityWebReqPathName())
+	}
+	return fmt.Sprintf("System.Func<%s, %s>", p.requestParamClassName(), p.unityWebReqPathName())
+}
+
+// RequestBuilder generates a fluent builder for the request. Required path
+// parameters each get their own interface, so they have to be set one after
+// another before the request can be sent.
+func (p Path) RequestBuilder() string {
+	className := p.requestBuilderClassName()
+	finalInterface := p.requestBuilderInterfaceName()
+
+	// What setting each required parameter moves the builder on to
+	stages := p.requiredStages()
+	next := make(map[string]string)
+	for i, param := range stages {
+		next[param.name] = finalInterface
+		if i < len(stages)-1 {
+			next[param.name] = p.requestBuilderStageName(stages[i+1])
+		}
+	}
+
+	builder := strings.Builder{}
+
+	// Everything that can be done once the required parameters are set
+	fmt.Fprintf(&builder, "public interface %s {\n", finalInterface)
+	for _, param := range p.parameters {
+		if _, ok := next[param.name]; ok == false {
+			fmt.Fprintf(&builder, "\t%s With%s(%s %s);\n", finalInterface, convention.TitleCase(param.name), param.parameterType.ToVariableType(), convention.CamelCase(param.name))
+		}
+	}
+	fmt.Fprintf(&builder, "\t%s WithHeader(string name, string value);\n", finalInterface)
+	fmt.Fprintf(&builder, "\t%s Send();\n}\n\n", p.unityWebReqPathName())
+
+	implements := []string{finalInterface}
+	for _, param := range stages {
+		fmt.Fprintf(&builder, "public interface %s {\n", p.requestBuilderStageName(param))
+		fmt.Fprintf(&builder, "\t%s With%s(%s %s);\n}\n\n", next[param.name], convention.TitleCase(param.name), param.parameterType.ToVariableType(), convention.CamelCase(param.name))
+		implements = append(implements, p.requestBuilderStageName(param))
+	}
+
+	fmt.Fprintf(&builder, "public class %s : %s {\n\n", className, strings.Join(implements, ", "))
+	fmt.Fprintf(&builder, "\tprivate %s send;\n\n", p.requestBuilderSender())
+	if len(p.parameters) > 0 {
+		fmt.Fprintf(&builder, "\tprivate %s requestParams = new %s();\n\n", p.requestParamClassName(), p.requestParamClassName())
+	}
+	builder.WriteString("\tprivate System.Collections.Generic.Dictionary<string, string> headers = new System.Collections.Generic.Dictionary<string, string>();\n\n")
+	fmt.Fprintf(&builder, "\tpublic %s(%s send) {\n\t\tthis.send = send;\n\t}\n\n", className, p.requestBuilderSender())
+
+	for _, param := range p.parameters {
+		returns, ok := next[param.name]
+		if ok == false {
+			returns = finalInterface
+		}
+		fmt.Fprintf(&builder, "\tpublic %s With%s(%s %s) {\n", returns, convention.TitleCase(param.name), param.parameterType.ToVariableType(), convention.CamelCase(param.name))
+		fmt.Fprintf(&builder, "\t\trequestParams.%s = %s;\n\t\treturn this;\n\t}\n\n", convention.TitleCase(param.name), convention.CamelCase(param.name))
+	}
+
+	fmt.Fprintf(&builder, "\tpublic %s WithHeader(string name, string value) {\n\t\theaders[name] = value;\n\t\treturn this;\n\t}\n\n", finalInterface)
+
+	// Sending goes through the service function, so the request is built and
+	// authorized the same way it'd be otherwise
+	fmt.Fprintf(&builder, "\tpublic %s Send() {\n", p.unityWebReqPathName())
+	if len(p.parameters) > 0 {
+		builder.WriteString("\t\tvar req = send(requestParams);\n")
+	} else {
+		builder.WriteString("\t\tvar req = send();\n")
+	}
+	builder.WriteString("\t\tforeach (var header in headers) {\n\t\t\treq.UnderlyingRequest.SetRequestHeader(header.Key, header.Value);\n\t\t}\n")
+	if p.Retries() {
+		// Retries are sent through the builder again so they keep its headers
+		builder.WriteString("\t\treturn req.RetryWith(req.RetryPolicy, Send);\n\t}\n\n}")
+	} else {
+		builder.WriteString("\t\treturn req;\n\t}\n\n}")
+	}
+
+	return builder.String()
+}
+
+// RequestBuilderServiceFunction generates the service function that starts
+// building a request fluently
+func (p Path) RequestBuilderServiceFunction() string {
+	return p.requestBuilderServiceFunction("")
+}
+
+// requestBuilderSignature declares the function that starts building the
+// request
+func (p Path) requestBuilderSignature(nested string) string {
+	return fmt.Sprintf("%s %sBuilder()", p.qualify(nested, p.firstRequestBuilderStage()), convention.ClassName(p.operationID))
+}
+
+func (p Path) requestBuilderServiceFunction(nested string) string {
+	return fmt.Sprintf(
+		"public %s\n{\n\treturn new %s(%s);\n}",
+		p.requestBuilderSignature(nested),
+		p.qualify(nested, p.requestBuilderClassName()),
+		convention.ClassName(p.operationID),
+	)
+}
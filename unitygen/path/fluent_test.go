@@ -0,0 +1,137 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestBuilderWithoutParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.RequestBuilder()
+	serviceFunction := route.RequestBuilderServiceFunction()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public interface IPingRequestBuilder {
+	IPingRequestBuilder WithHeader(string name, string value);
+	PingUnityWebRequest Send();
+}
+
+public class PingRequestBuilder : IPingRequestBuilder {
+
+	private System.Func<PingUnityWebRequest> send;
+
+	private System.Collections.Generic.Dictionary<string, string> headers = new System.Collections.Generic.Dictionary<string, string>();
+
+	public PingRequestBuilder(System.Func<PingUnityWebRequest> send) {
+		this.send = send;
+	}
+
+	public IPingRequestBuilder WithHeader(string name, string value) {
+		headers[name] = value;
+		return this;
+	}
+
+	public PingUnityWebRequest Send() {
+		var req = send();
+		foreach (var header in headers) {
+			req.UnderlyingRequest.SetRequestHeader(header.Key, header.Value);
+		}
+		return req.RetryWith(req.RetryPolicy, Send);
+	}
+
+}`, code)
+	assert.Equal(t, `public IPingRequestBuilder PingBuilder()
+{
+	return new PingRequestBuilder(Ping);
+}`, serviceFunction)
+}
+
+func Test_RequestBuilderStagesRequiredPathParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users/{userId}/recordings/{recordingId}",
+		"GetRecording",
+		http.MethodGet,
+		nil,
+		nil,
+		nil,
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
+			path.NewParameter(path.QueryParameterLocation, "include_meta", false, property.NewBoolean("include_meta")),
+			path.NewParameter(path.PathParameterLocation, "recordingId", true, property.NewString("recordingId", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.RequestBuilder()
+	serviceFunction := route.RequestBuilderServiceFunction()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public interface IGetRecordingRequestBuilder {
+	IGetRecordingRequestBuilder WithIncludeMeta(bool includeMeta);
+	IGetRecordingRequestBuilder WithHeader(string name, string value);
+	GetRecordingUnityWebRequest Send();
+}
+
+public interface IGetRecordingRequestBuilderNeedsUserId {
+	IGetRecordingRequestBuilderNeedsRecordingId WithUserId(string userId);
+}
+
+public interface IGetRecordingRequestBuilderNeedsRecordingId {
+	IGetRecordingRequestBuilder WithRecordingId(string recordingId);
+}
+
+public class GetRecordingRequestBuilder : IGetRecordingRequestBuilder, IGetRecordingRequestBuilderNeedsUserId, IGetRecordingRequestBuilderNeedsRecordingId {
+
+	private System.Func<GetRecordingRequestParams, GetRecordingUnityWebRequest> send;
+
+	private GetRecordingRequestParams requestParams = new GetRecordingRequestParams();
+
+	private System.Collections.Generic.Dictionary<string, string> headers = new System.Collections.Generic.Dictionary<string, string>();
+
+	public GetRecordingRequestBuilder(System.Func<GetRecordingRequestParams, GetRecordingUnityWebRequest> send) {
+		this.send = send;
+	}
+
+	public IGetRecordingRequestBuilderNeedsRecordingId WithUserId(string userId) {
+		requestParams.UserId = userId;
+		return this;
+	}
+
+	public IGetRecordingRequestBuilder WithIncludeMeta(bool includeMeta) {
+		requestParams.IncludeMeta = includeMeta;
+		return this;
+	}
+
+	public IGetRecordingRequestBuilder WithRecordingId(string recordingId) {
+		requestParams.RecordingId = recordingId;
+		return this;
+	}
+
+	public IGetRecordingRequestBuilder WithHeader(string name, string value) {
+		headers[name] = value;
+		return this;
+	}
+
+	public GetRecordingUnityWebRequest Send() {
+		var req = send(requestParams);
+		foreach (var header in headers) {
+			req.UnderlyingRequest.SetRequestHeader(header.Key, header.Value);
+		}
+		return req.RetryWith(req.RetryPolicy, Send);
+	}
+
+}`, code)
+	assert.Equal(t, `public IGetRecordingRequestBuilderNeedsUserId GetRecordingBuilder()
+{
+	return new GetRecordingRequestBuilder(GetRecording);
+}`, serviceFunction)
+}
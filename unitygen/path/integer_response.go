@@ -0,0 +1,28 @@
+package path
+
+import "fmt"
+
+// IntegerResponse is a type of response that expects a whole number.
+type IntegerResponse struct {
+	description string
+}
+
+// NewIntegerResponse creates a new integer response
+func NewIntegerResponse(description string) IntegerResponse {
+	return IntegerResponse{
+		description: description,
+	}
+}
+
+// Description of the response
+func (resp IntegerResponse) Description() string {
+	return resp.description
+}
+
+func (resp IntegerResponse) Interpret(variableName string, downloadHandlerVariableName string) string {
+	return fmt.Sprintf("%s = int.Parse(%s, System.Globalization.CultureInfo.InvariantCulture);", variableName, plainTextBody(downloadHandlerVariableName))
+}
+
+func (resp IntegerResponse) VariableType() string {
+	return "int"
+}
@@ -0,0 +1,23 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_IntegerResponse(t *testing.T) {
+	// ARRANGE ================================================================
+	desciption := "A bunch of cool cats"
+	defResp := path.NewIntegerResponse(desciption)
+
+	// ACT ====================================================================
+	desc := defResp.Description()
+	interpret := defResp.Interpret("somethin", "download")
+
+	// ASSERT =================================================================
+	assert.Equal(t, desciption, desc)
+	assert.Equal(t, "somethin = int.Parse(download.text.Trim(), System.Globalization.CultureInfo.InvariantCulture);", interpret)
+	assert.Equal(t, "int", defResp.VariableType())
+}
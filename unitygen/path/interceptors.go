@@ -0,0 +1,94 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InterceptorClasses is the C# every request is run through on its way out
+// and back in
+const InterceptorClasses = `// IRequestInterceptor is given every request right before it's sent and
+// every response before it's interpreted, for anything that applies across
+// all requests (correlation IDs, signing, logging, metrics)
+public interface IRequestInterceptor {
+
+	// BeforeSend can modify the request, and wait on anything it needs first.
+	// Awaited requests only wait on nested IEnumerators and AsyncOperations,
+	// so yield WaitForSecondsRealtime instead of WaitForSeconds, and the
+	// IEnumerator itself instead of a started Coroutine.
+	IEnumerator BeforeSend(UnityWebRequest req);
+
+	// AfterReceive can inspect the response. Returning false handles it,
+	// skipping the interceptors after this one, and the body isn't interpreted.
+	bool AfterReceive(UnityWebRequest req);
+}
+
+// RequestInterceptors runs the interceptors registered, in the order they
+// were added
+public class RequestInterceptors {
+
+	private readonly System.Collections.Generic.List<IRequestInterceptor> interceptors = new System.Collections.Generic.List<IRequestInterceptor>();
+
+	public int Count { get { return interceptors.Count; } }
+
+	public void Add(IRequestInterceptor interceptor) {
+		if (interceptor == null) {
+			throw new System.ArgumentNullException("interceptor");
+		}
+		interceptors.Add(interceptor);
+	}
+
+	public bool Remove(IRequestInterceptor interceptor) {
+		return interceptors.Remove(interceptor);
+	}
+
+	public void Clear() {
+		interceptors.Clear();
+	}
+
+	public IEnumerator BeforeSend(UnityWebRequest req) {
+		// Interceptors are free to remove themselves while running
+		foreach (var interceptor in interceptors.ToArray()) {
+			yield return interceptor.BeforeSend(req);
+		}
+	}
+
+	// AfterReceive is whether or not the response still needs interpreting
+	public bool AfterReceive(UnityWebRequest req) {
+		foreach (var interceptor in interceptors.ToArray()) {
+			if (interceptor.AfterReceive(req) == false) {
+				return false;
+			}
+		}
+		return true;
+	}
+}`
+
+// interceptorFields are the members a request uses to run through the
+// config's interceptors
+func (p Path) interceptorFields() string {
+	builder := strings.Builder{}
+	builder.WriteString("\tprivate RequestInterceptors interceptors;\n\n")
+	builder.WriteString("\t// InterceptWith runs the request and its response through the interceptors\n")
+	fmt.Fprintf(&builder, "\tpublic %s InterceptWith(RequestInterceptors interceptors) {\n", p.unityWebReqPathName())
+	builder.WriteString("\t\tthis.interceptors = interceptors;\n\t\treturn this;\n\t}\n\n")
+	return builder.String()
+}
+
+// interceptBeforeSend hands the request to the interceptors before it's sent
+func (p Path) interceptBeforeSend(indent string) string {
+	return fmt.Sprintf(
+		"%sif (this.interceptors != null) {\n%s\tyield return this.interceptors.BeforeSend(this.UnderlyingRequest);\n%s}\n",
+		indent, indent, indent,
+	)
+}
+
+// interceptAfterReceive hands the response to the interceptors, stopping the
+// request from being interpreted when one of them handles it
+func (p Path) interceptAfterReceive(indent string) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "%sif (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {\n", indent)
+	fmt.Fprintf(&builder, "%s\tResponse = new %s(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);\n", indent, p.apiResponseType())
+	fmt.Fprintf(&builder, "%s\tyield break;\n%s}\n", indent, indent)
+	return builder.String()
+}
@@ -0,0 +1,52 @@
+package path_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InterceptorsSurroundSendingTheRequest(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		indent string
+	}{
+		"sent once": {method: http.MethodPost, indent: "\t\t\t"},
+		"retryable": {method: http.MethodGet, indent: "\t\t\t\t"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ******************************** ARRANGE *******************************
+			route := path.NewPath("/api/v1/users", "GetUser", tc.method, nil, nil, nil, nil, path.PathOptions{})
+
+			// ********************************** ACT *********************************
+			code := route.UnityWebRequest(false)
+
+			// ********************************* ASSERT *******************************
+			assert.Contains(t, code, "\tpublic GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {\n")
+
+			beforeSend := strings.Index(code, tc.indent+"\tyield return this.interceptors.BeforeSend(this.UnderlyingRequest);\n")
+			send := strings.Index(code, tc.indent+"yield return this.UnderlyingRequest.SendWebRequest();\n")
+			afterReceive := strings.Index(code, tc.indent+"if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {\n")
+			interpret := strings.Index(code, tc.indent+"Interpret(this.UnderlyingRequest);\n")
+			assert.True(t, beforeSend > 0 && beforeSend < send, "interceptors should see the request before it's sent")
+			assert.True(t, send < afterReceive && afterReceive < interpret, "interceptors should see the response before it's interpreted")
+			assert.Contains(t, code, tc.indent+"\tResponse = new ApiResponse<object, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);\n"+tc.indent+"\tyield break;\n")
+		})
+	}
+}
+
+func Test_ServiceFunctionsRunThroughTheConfigsInterceptors(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.ServiceFunction(nil)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\treturn new CreateUserUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors);\n")
+}
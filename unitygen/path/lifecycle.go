@@ -0,0 +1,59 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lifecycleFields are the members a request uses to be aborted and to free
+// the native memory Unity holds for it
+func (p Path) lifecycleFields() string {
+	builder := strings.Builder{}
+	builder.WriteString("\t// Whether or not the underlying request is disposed once Run is done. Turn\n")
+	builder.WriteString("\t// it off to keep reading from it afterwards, and dispose of it yourself.\n")
+	builder.WriteString("\tpublic bool DisposeOnComplete { get; set; }\n\n")
+	builder.WriteString("\tprivate bool cancelled;\n\n")
+	builder.WriteString("\tprivate bool disposed;\n\n")
+	builder.WriteString("\t// Abort stops the request, ending Run with a cancelled result\n")
+	builder.WriteString("\tpublic void Abort() {\n")
+	builder.WriteString("\t\tcancelled = true;\n")
+	builder.WriteString("\t\tif (disposed == false) {\n\t\t\tthis.UnderlyingRequest.Abort();\n\t\t}\n")
+	builder.WriteString("\t}\n\n")
+	builder.WriteString("\t// Dispose frees the underlying request along with its upload and download\n")
+	builder.WriteString("\t// handlers\n")
+	builder.WriteString("\tpublic void Dispose() {\n")
+	builder.WriteString("\t\tif (disposed) {\n\t\t\treturn;\n\t\t}\n")
+	builder.WriteString("\t\tdisposed = true;\n")
+	builder.WriteString("\t\tthis.UnderlyingRequest.Dispose();\n")
+	builder.WriteString("\t}\n\n")
+	return builder.String()
+}
+
+// cancelCheck ends Run early once the request has been aborted
+func (p Path) cancelCheck(indent string) string {
+	return fmt.Sprintf("%sif (cancelled) {\n%s\tyield break;\n%s}\n", indent, indent, indent)
+}
+
+// runWithCleanup is the Run function, marking the result as cancelled and
+// disposing of the request however the body ends. A coroutine stopped with
+// StopCoroutine, or by destroying its MonoBehaviour, is never resumed though,
+// so the finally block doesn't run in that case. Requests that fake services
+// can respond to don't send anything once they have been.
+func (p Path) runWithCleanup(body string, fakes bool) string {
+	builder := strings.Builder{}
+	builder.WriteString("\tpublic IEnumerator Run() {\n")
+	builder.WriteString("\t\ttry {\n")
+	if fakes {
+		builder.WriteString("\t\t\tif (responded) {\n\t\t\t\tyield break;\n\t\t\t}\n")
+	}
+	builder.WriteString(body)
+	builder.WriteString("\t\t} finally {\n")
+	builder.WriteString("\t\t\tif (cancelled) {\n")
+	fmt.Fprintf(&builder, "\t\t\t\tResponse = new %s(this.UnderlyingRequest);\n", p.apiResponseType())
+	builder.WriteString("\t\t\t\tResponse.Cancelled = true;\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t\tif (DisposeOnComplete) {\n\t\t\t\tDispose();\n\t\t\t}\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t}\n\n")
+	return builder.String()
+}
@@ -0,0 +1,89 @@
+package path_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RequestsDisposeOnceDoneByDefault(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\t\tthis.DisposeOnComplete = true;\n")
+	assert.Contains(t, code, "\tpublic void Dispose() {\n\t\tif (disposed) {\n\t\t\treturn;\n\t\t}\n\t\tdisposed = true;\n\t\tthis.UnderlyingRequest.Dispose();\n\t}\n")
+	assert.Contains(t, code, `		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<object, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
+`)
+}
+
+func Test_AbortingEndsRunBeforeAndAfterSending(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		indent string
+		checks int
+	}{
+		"sent once": {method: http.MethodPost, indent: "\t\t\t", checks: 2},
+		"retryable": {method: http.MethodGet, indent: "\t\t\t\t", checks: 3},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ******************************** ARRANGE *******************************
+			route := path.NewPath("/api/v1/users", "GetUser", tc.method, nil, nil, nil, nil, path.PathOptions{})
+			cancelCheck := tc.indent + "if (cancelled) {\n" + tc.indent + "\tyield break;\n" + tc.indent + "}\n"
+			send := tc.indent + "sentAt = Time.realtimeSinceStartup;\n" + tc.indent + "yield return this.UnderlyingRequest.SendWebRequest();\n"
+
+			// ********************************** ACT *********************************
+			code := route.UnityWebRequest(false)
+
+			// ********************************* ASSERT *******************************
+			assert.Contains(t, code, "\tpublic void Abort() {\n\t\tcancelled = true;\n\t\tif (disposed == false) {\n\t\t\tthis.UnderlyingRequest.Abort();\n\t\t}\n\t}\n")
+			assert.Contains(t, code, cancelCheck+send+cancelCheck)
+			assert.Equal(t, tc.checks, strings.Count(code, cancelCheck))
+		})
+	}
+}
+
+func Test_RetriesStopOnceAborted(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/users", "GetUser", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\t\t\t\tyield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));\n\t\t\t\tif (cancelled) {\n\t\t\t\t\tyield break;\n\t\t\t\t}\n")
+	assert.Contains(t, path.RetryPolicyClass, "if (response.Cancelled || attempts >= MaxAttempts) {")
+}
+
+func Test_RunnersLeaveCleaningUpToTheRequest(t *testing.T) {
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, path.TaskAsync.RunnerClass(), "\t\t\trequest.Abort();\n")
+	assert.NotContains(t, path.TaskAsync.RunnerClass(), "UnderlyingRequest.Abort()")
+	assert.Contains(t, path.CallbackClasses, "\t\tvar dispose = request.DisposeOnComplete;\n\t\trequest.DisposeOnComplete = false;\n")
+	assert.Contains(t, path.CallbackClasses, "\t\t} finally {\n\t\t\tif (dispose) {\n\t\t\t\trequest.Dispose();\n\t\t\t}\n\t\t}\n")
+}
+
+func Test_AwaitedRequestsAreHandedBackUndisposed(t *testing.T) {
+	// ********************************** ACT *********************************
+	code := path.TaskAsync.RunnerClass()
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "where T : IWebRequest {\n\t\trequest.DisposeOnComplete = false;\n")
+	assert.Contains(t, code, "\t\t} catch {\n\t\t\trequest.Dispose();\n\t\t\tthrow;\n\t\t}\n\t\treturn request;\n")
+}
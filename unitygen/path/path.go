@@ -18,16 +18,35 @@ type Path struct {
 	httpMethod  string
 	tags        []string
 	operationID string
-	security    []SecurityMethodReference
+	security    []SecurityRequirement
 
 	// mapping of HTTP Status Codes to responses
 	responses map[string]Response
 
+	// mapping of HTTP Status Codes to the headers found in their response
+	responseHeaders map[string][]ResponseHeader
+
 	parameters []Parameter
+
+	// Whether or not the request is retried when it fails, decided by the
+	// HTTP method when left nil
+	retry *bool
+}
+
+// PathOptions are the optional parts of a path that the spec doesn't need to
+// declare
+type PathOptions struct {
+	// Mapping of HTTP Status Codes to the headers found in their response
+	ResponseHeaders map[string][]ResponseHeader
+
+	// Whether or not the request is retried when it fails, decided by the
+	// HTTP method when left nil
+	Retry *bool
 }
 
-// NewPath creates a new path
-func NewPath(route, operationID, method string, tags []string, security []SecurityMethodReference, responses map[string]Response, parameters []Parameter) Path {
+// NewPath creates a new path. Any one of the security requirements being
+// satisfied authorizes the request.
+func NewPath(route, operationID, method string, tags []string, security []SecurityRequirement, responses map[string]Response, parameters []Parameter, options PathOptions) Path {
 	bodyFound := false
 	for _, p := range parameters {
 		if p.location == BodyParameterLocation {
@@ -39,13 +58,15 @@ func NewPath(route, operationID, method string, tags []string, security []Securi
 	}
 
 	return Path{
-		route:       route,
-		httpMethod:  method,
-		operationID: operationID,
-		security:    security,
-		tags:        tags,
-		responses:   responses,
-		parameters:  parameters,
+		route:           route,
+		httpMethod:      method,
+		operationID:     operationID,
+		security:        security,
+		tags:            tags,
+		responses:       responses,
+		responseHeaders: options.ResponseHeaders,
+		parameters:      parameters,
+		retry:           options.Retry,
 	}
 }
 
@@ -66,13 +87,76 @@ func (p Path) Method() string {
 }
 
 func (p Path) SecurityReferences() []SecurityMethodReference {
+	references := make([]SecurityMethodReference, 0)
+	found := make(map[string]bool)
+	for _, requirement := range p.security {
+		for _, ref := range requirement {
+			if found[ref.Identifier] {
+				continue
+			}
+			found[ref.Identifier] = true
+			references = append(references, ref)
+		}
+	}
+	return references
+}
+
+// SecurityRequirements are the alternative sets of security methods that can
+// authorize a request, in order of preference
+func (p Path) SecurityRequirements() []SecurityRequirement {
 	return p.security
 }
 
+// secured is whether or not the path has any requirement with methods in it
+func (p Path) secured() bool {
+	return len(p.SecurityReferences()) > 0
+}
+
+// allowsAnonymous is whether or not one of the path's requirements is empty,
+// meaning the request can be made without any credentials
+func (p Path) allowsAnonymous() bool {
+	for _, requirement := range p.security {
+		if len(requirement) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (p Path) Responses() map[string]Response {
 	return p.responses
 }
 
+// ResponseHeaders are the headers a route declares it will respond with,
+// mapped by status code
+func (p Path) ResponseHeaders() map[string][]ResponseHeader {
+	return p.responseHeaders
+}
+
+// uniqueResponseHeaders collects every header across all responses. Headers
+// shared between status codes are only listed once.
+func (p Path) uniqueResponseHeaders() []ResponseHeader {
+	seen := make(map[string]bool)
+	headers := make([]ResponseHeader, 0)
+	for _, code := range p.orderedResponseCodes() {
+		for _, header := range p.responseHeaders[code] {
+			if seen[header.propertyName()] {
+				continue
+			}
+			seen[header.propertyName()] = true
+			headers = append(headers, header)
+		}
+	}
+	sort.Sort(sortByHeaderName(headers))
+	return headers
+}
+
+type sortByHeaderName []ResponseHeader
+
+func (a sortByHeaderName) Len() int           { return len(a) }
+func (a sortByHeaderName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a sortByHeaderName) Less(i, j int) bool { return a[i].name < a[j].name }
+
 // Tags are what different tags a route is associated with
 func (p Path) Tags() []string {
 	return p.tags
@@ -86,37 +170,116 @@ func (p Path) requestParamClassName() string {
 	return fmt.Sprintf("%sRequestParams", convention.ClassName(p.operationID))
 }
 
-func (p Path) respVariableName(k string) string {
-	switch k {
-	case "200":
-		return "success"
-
-	case "400":
-		return "badRequest"
-	case "401":
-		return "unauthorized"
-	case "403":
-		return "forbidden"
-	case "404":
-		return "notFound"
+// statusCodeNames are the variable names given to responses of well known
+// status codes. Anything not found here falls back to status<code>.
+var statusCodeNames = map[string]string{
+	"200":     "success",
+	"201":     "created",
+	"202":     "accepted",
+	"203":     "nonAuthoritativeInformation",
+	"204":     "noContent",
+	"205":     "resetContent",
+	"206":     "partialContent",
+	"300":     "multipleChoices",
+	"301":     "movedPermanently",
+	"302":     "found",
+	"303":     "seeOther",
+	"304":     "notModified",
+	"307":     "temporaryRedirect",
+	"308":     "permanentRedirect",
+	"400":     "badRequest",
+	"401":     "unauthorized",
+	"402":     "paymentRequired",
+	"403":     "forbidden",
+	"404":     "notFound",
+	"405":     "methodNotAllowed",
+	"406":     "notAcceptable",
+	"407":     "proxyAuthenticationRequired",
+	"408":     "requestTimeout",
+	"409":     "conflict",
+	"410":     "gone",
+	"411":     "lengthRequired",
+	"412":     "preconditionFailed",
+	"413":     "payloadTooLarge",
+	"414":     "uriTooLong",
+	"415":     "unsupportedMediaType",
+	"416":     "rangeNotSatisfiable",
+	"417":     "expectationFailed",
+	"422":     "unprocessableEntity",
+	"423":     "locked",
+	"424":     "failedDependency",
+	"425":     "tooEarly",
+	"426":     "upgradeRequired",
+	"428":     "preconditionRequired",
+	"429":     "tooManyRequests",
+	"431":     "requestHeaderFieldsTooLarge",
+	"451":     "unavailableForLegalReasons",
+	"500":     "internalServerError",
+	"501":     "notImplemented",
+	"502":     "badGateway",
+	"503":     "serviceUnavailable",
+	"504":     "gatewayTimeout",
+	"505":     "httpVersionNotSupported",
+	"507":     "insufficientStorage",
+	"511":     "networkAuthenticationRequired",
+	"1XX":     "informational",
+	"2XX":     "successful",
+	"3XX":     "redirection",
+	"4XX":     "clientError",
+	"5XX":     "serverError",
+	"default": "fallbackResponse",
+}
 
-	case "500":
-		return "internalServerError"
-	case "501":
-		return "notImplemented"
-	case "502":
-		return "badGateway"
-	case "503":
-		return "serviceUnavailable"
-	case "504":
-		return "gatewayTimeout"
+// IsStatusCodeRange determines whether or not the response key covers an
+// entire class of status codes (ie: 2XX, 4XX)
+func IsStatusCodeRange(code string) bool {
+	return len(code) == 3 && code[0] >= '1' && code[0] <= '5' && strings.ToUpper(code[1:]) == "XX"
+}
 
-	case "default":
-		return "fallbackResponse"
+// IsValidResponseKey determines whether or not the key found in a route's
+// responses can be interpretted
+func IsValidResponseKey(code string) bool {
+	if code == "default" || IsStatusCodeRange(code) {
+		return true
+	}
+	parsed, err := strconv.Atoi(code)
+	return err == nil && parsed >= 100 && parsed <= 599
+}
 
+func (p Path) respVariableName(k string) string {
+	key := k
+	if IsStatusCodeRange(k) {
+		key = strings.ToUpper(k)
 	}
+	if name, ok := statusCodeNames[key]; ok {
+		return name
+	}
+	return "status" + key
+}
 
-	panic("unknown response key: " + k)
+// orderedResponseCodes lays out response keys in the order they should be
+// checked: specific status codes, then status code ranges, and finally the
+// default response
+func (p Path) orderedResponseCodes() []string {
+	codes := make([]string, 0)
+	ranges := make([]string, 0)
+	hasDefault := false
+	for k := range p.responses {
+		if k == "default" {
+			hasDefault = true
+		} else if IsStatusCodeRange(k) {
+			ranges = append(ranges, k)
+		} else {
+			codes = append(codes, k)
+		}
+	}
+	sort.Strings(codes)
+	sort.Strings(ranges)
+	codes = append(codes, ranges...)
+	if hasDefault {
+		codes = append(codes, "default")
+	}
+	return codes
 }
 
 func (p Path) queryParamCount() int {
@@ -210,19 +373,15 @@ func (p Path) RequestParamClass() string {
 	return builder.String()
 }
 
-// UnityWebRequest is the CSharp code that handles asynchronous web requests
-func (p Path) UnityWebRequest() string {
+// UnityWebRequest is the CSharp code that handles asynchronous web requests.
+// With fakes, fake services can also respond to it without it being sent.
+func (p Path) UnityWebRequest(fakes bool) string {
 	builder := strings.Builder{}
 
 	fmt.Fprintf(&builder, "public class %s : IWebRequest {\n\n", p.unityWebReqPathName())
 
 	// Outline all portential responses
-	keys := make([]string, 0)
-	for k := range p.responses {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	for _, k := range keys {
+	for _, k := range p.orderedResponseCodes() {
 		// Some responses are defined as an empty body, making them nil!
 		if p.responses[k] != nil {
 			desc := strings.TrimSpace(p.responses[k].Description())
@@ -237,78 +396,152 @@ func (p Path) UnityWebRequest() string {
 		}
 	}
 
-	// underlying network request
-	fmt.Fprint(&builder, "\tpublic UnityWebRequest UnderlyingRequest{ get; }\n\n")
+	// Typed headers found in the response
+	headers := p.uniqueResponseHeaders()
+	for _, header := range headers {
+		builder.WriteString(header.ClassVariables())
+		builder.WriteString("\n")
+	}
 
-	// constructor
-	fmt.Fprintf(&builder, "\tpublic %s(UnityWebRequest req) {\n\t\tthis.UnderlyingRequest = req;\n\t}\n\n", p.unityWebReqPathName())
+	// Result of the request, once it's been interpreted
+	fmt.Fprintf(&builder, "\tpublic %s Response { get; private set; }\n\n", p.apiResponseType())
+	builder.WriteString("\tIApiResponse IWebRequest.Response { get { return Response; } }\n\n")
+
+	// underlying network request, replaced with a fresh one on every retry
+	if p.Retries() {
+		fmt.Fprint(&builder, "\tpublic UnityWebRequest UnderlyingRequest{ get; private set; }\n\n")
+	} else {
+		fmt.Fprint(&builder, "\tpublic UnityWebRequest UnderlyingRequest{ get; }\n\n")
+	}
+
+	// when the underlying request was last sent, for telling timeouts apart
+	builder.WriteString("\tprivate float sentAt;\n\n")
+
+	if p.secured() {
+		// Secured requests might need to wait on credentials before being sent
+		builder.WriteString("\tprivate IRequestAuthorizer[] authorizers;\n\n")
+		fmt.Fprintf(&builder, "\tpublic %s(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {\n\t\tthis.UnderlyingRequest = req;\n\t\tthis.authorizers = authorizers;\n\t\tthis.DisposeOnComplete = true;\n\t}\n\n", p.unityWebReqPathName())
+	} else {
+		// constructor
+		fmt.Fprintf(&builder, "\tpublic %s(UnityWebRequest req) {\n\t\tthis.UnderlyingRequest = req;\n\t\tthis.DisposeOnComplete = true;\n\t}\n\n", p.unityWebReqPathName())
+	}
+
+	builder.WriteString(p.interceptorFields())
+
+	builder.WriteString(p.lifecycleFields())
+
+	if fakes {
+		builder.WriteString(p.respondFields())
+	}
 
 	// Function that will actually execute the request
-	builder.WriteString("\tpublic IEnumerator Run() {\n\t\tyield return this.UnderlyingRequest.SendWebRequest();\n")
-	if len(p.responses) > 0 {
-		builder.WriteString("\t\tInterpret(this.UnderlyingRequest);\n")
+	if p.Retries() {
+		builder.WriteString(p.retryFields())
+		builder.WriteString(p.runWithCleanup(p.retryRun("\t\t\t"), fakes))
+	} else {
+		builder.WriteString(p.runWithCleanup(p.sendAttempt("\t\t\t"), fakes))
 	}
-	builder.WriteString("\t}\n\n")
 
+	// Interpreting never throws, anything that goes wrong ends up on the result
+	builder.WriteString("\tpublic void Interpret(UnityWebRequest req) {\n")
+	if p.Retries() {
+		builder.WriteString(p.retryReset())
+	}
+	fmt.Fprintf(&builder, "\t\tResponse = new %s(req, Time.realtimeSinceStartup - sentAt);\n", p.apiResponseType())
+	if len(p.responses) > 0 || len(headers) > 0 {
+		// Nothing came back worth interpreting
+		builder.WriteString("\t\tif (Response.NetworkError != null) {\n\t\t\treturn;\n\t\t}\n")
+	}
 	if len(p.responses) > 0 {
-		builder.WriteString("\tpublic void Interpret(UnityWebRequest req) {\n")
+		builder.WriteString("\t\ttry {\n")
 		builder.WriteString(p.renderHandleResponse())
-		builder.WriteString("\t}\n\n")
+		builder.WriteString("\t\t} catch (System.Exception e) {\n\t\t\tResponse.ParseException = e;\n\t\t}\n")
 	}
+	for _, header := range headers {
+		builder.WriteString(header.Interpret("req"))
+	}
+	builder.WriteString("\t}\n\n")
 
 	builder.WriteString("}")
 
 	return builder.String()
 }
 
-func (p Path) renderConditionalResponseCast(code string, resp Response) string {
-	if parsed, err := strconv.Atoi(code); err == nil {
-		if resp != nil {
-			return fmt.Sprintf("if (req.responseCode == %d) {\n\t\t\t%s\n\t\t}", parsed, resp.Interpret(p.respVariableName(code), "req.downloadHandler"))
-		} else {
-			return fmt.Sprintf("if (req.responseCode == %d) {\n\t\t\t%s\n\t\t}", parsed, "// No expected response. Do nothing!")
-		}
+// sendAttempt authorizes, sends and interprets the request once
+func (p Path) sendAttempt(indent string) string {
+	builder := strings.Builder{}
+	if p.secured() {
+		fmt.Fprintf(&builder, "%sforeach (var authorizer in this.authorizers) {\n%s\tyield return authorizer.Authorize(this.UnderlyingRequest);\n%s}\n", indent, indent, indent)
+	}
+	builder.WriteString(p.interceptBeforeSend(indent))
+	builder.WriteString(p.cancelCheck(indent))
+	fmt.Fprintf(&builder, "%ssentAt = Time.realtimeSinceStartup;\n", indent)
+	fmt.Fprintf(&builder, "%syield return this.UnderlyingRequest.SendWebRequest();\n", indent)
+	builder.WriteString(p.cancelCheck(indent))
+	if p.secured() {
+		fmt.Fprintf(&builder, "%sforeach (var authorizer in this.authorizers) {\n%s\tauthorizer.Inspect(this.UnderlyingRequest);\n%s}\n", indent, indent, indent)
+	}
+	builder.WriteString(p.interceptAfterReceive(indent))
+	fmt.Fprintf(&builder, "%sInterpret(this.UnderlyingRequest);\n", indent)
+	return builder.String()
+}
+
+func (p Path) responseCondition(code string) string {
+	return p.statusCondition(code, "req.responseCode")
+}
+
+// statusCondition is whether or not the status code in the variable belongs
+// to the response
+func (p Path) statusCondition(code, variable string) string {
+	if IsStatusCodeRange(code) {
+		lowerBound := int(code[0]-'0') * 100
+		return fmt.Sprintf("%s >= %d && %s < %d", variable, lowerBound, variable, lowerBound+100)
+	}
+	parsed, _ := strconv.Atoi(code)
+	return fmt.Sprintf("%s == %d", variable, parsed)
+}
+
+func (p Path) renderResponseCast(code string) string {
+	resp := p.responses[code]
+	if resp == nil {
+		return "// No expected response. Do nothing!"
 	}
-	return resp.Interpret("fallbackResponse", "req.downloadHandler")
+	return resp.Interpret(p.respVariableName(code), "req.downloadHandler")
 }
 
 func (p Path) renderHandleResponse() string {
-	codes := make([]string, 0)
-	for k := range p.responses {
-		codes = append(codes, k)
+	codes := p.orderedResponseCodes()
+	if len(codes) == 0 {
+		return ""
 	}
-	builder := strings.Builder{}
-	sort.Strings(codes)
-	if len(codes) > 0 {
-		builder.WriteString("\t\t")
+
+	// A lone default response catches everything
+	if len(codes) == 1 && codes[0] == "default" {
+		return fmt.Sprintf("\t\t\t%s%s\n", p.renderResponseCast(codes[0]), p.renderResponseResult(codes[0], "req.responseCode", "\t\t\t"))
 	}
 
-	if len(codes) == 1 {
-		builder.WriteString(p.renderConditionalResponseCast(codes[0], p.responses[codes[0]]))
-		builder.WriteString("\n")
-	} else if len(codes) > 1 {
-		for codeIndex, code := range codes {
-			if code == "default" {
-				builder.WriteString("{\n\t\t\t")
-				builder.WriteString(p.renderConditionalResponseCast(code, p.responses[codes[codeIndex]]))
-				builder.WriteString("\n\t\t}")
-			} else {
-				builder.WriteString(p.renderConditionalResponseCast(code, p.responses[codes[codeIndex]]))
-			}
-			if codeIndex < len(codes)-1 {
-				builder.WriteString(" else ")
-			}
+	builder := strings.Builder{}
+	builder.WriteString("\t\t\t")
+	for codeIndex, code := range codes {
+		if code == "default" {
+			// Default only catches what no other branch has claimed
+			fmt.Fprintf(&builder, "{\n\t\t\t\t%s%s\n\t\t\t}", p.renderResponseCast(code), p.renderResponseResult(code, "req.responseCode", "\t\t\t\t"))
+		} else {
+			fmt.Fprintf(&builder, "if (%s) {\n\t\t\t\t%s%s\n\t\t\t}", p.responseCondition(code), p.renderResponseCast(code), p.renderResponseResult(code, "req.responseCode", "\t\t\t\t"))
+		}
+		if codeIndex < len(codes)-1 {
+			builder.WriteString(" else ")
 		}
-		builder.WriteString("\n")
 	}
+	builder.WriteString("\n")
 	return builder.String()
 }
 
 // SupportingClasses will write out different helper classes in C# to assist
 // in network requests
-func (p Path) SupportingClasses() string {
+func (p Path) SupportingClasses(fakes bool) string {
 	builder := strings.Builder{}
-	builder.WriteString(p.UnityWebRequest())
+	builder.WriteString(p.UnityWebRequest(fakes))
 	builder.WriteString("\n")
 	builder.WriteString(p.RequestParamClass())
 	return builder.String()
@@ -323,6 +556,48 @@ func (p Path) guard(reference SecurityMethodReference, knownModifiers []security
 	panic("no known modifier matches reference " + reference.Identifier)
 }
 
+// renderSecurityRequirements writes out C# that applies the first requirement
+// the config has credentials for, and fails when there are none (unless the
+// request can be made anonymously).
+func (p Path) renderSecurityRequirements(knownModifiers []security.Auth) string {
+	builder := strings.Builder{}
+	alternatives := make([]string, 0)
+
+	builder.WriteString("\t")
+	for _, requirement := range p.security {
+		if len(requirement) == 0 {
+			continue
+		}
+
+		conditions := make([]string, len(requirement))
+		for i, ref := range requirement {
+			conditions[i] = p.guard(ref, knownModifiers).CredentialsAvailable()
+		}
+
+		if len(alternatives) > 0 {
+			builder.WriteString(" else ")
+		}
+		fmt.Fprintf(&builder, "if (%s) {\n", strings.Join(conditions, " && "))
+		for _, ref := range requirement {
+			guard := p.guard(ref, knownModifiers)
+			fmt.Fprintf(&builder, "\t\t%s\n", guard.ModifyNetworkRequest())
+			if authorizer, ok := guard.(security.RequestAuthorizer); ok {
+				fmt.Fprintf(&builder, "\t\tauthorizers.Add(this.Config.%s);\n", authorizer.StateName())
+			}
+		}
+		builder.WriteString("\t}")
+		alternatives = append(alternatives, requirement.String())
+	}
+
+	if p.allowsAnonymous() == false {
+		message := fmt.Sprintf("unable to authorize %s, the config has no credentials for %s", convention.ClassName(p.operationID), strings.Join(alternatives, " or "))
+		fmt.Fprintf(&builder, " else {\n\t\tthrow new System.InvalidOperationException(%s);\n\t}", strconv.Quote(message))
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
 func (p Path) serviceFunctionParameters() string {
 	if len(p.parameters) == 0 {
 		return ""
@@ -339,11 +614,35 @@ func (p Path) serviceFunctionParameters() string {
 	return sb.String()
 }
 
-func (p Path) functionOveride(knownModifiers []security.Auth) string {
+// qualify refers to a type nested in the service from outside of it, nested
+// being the service's class name. Inside the service it's left as is.
+func (p Path) qualify(nested, typeName string) string {
+	if nested == "" {
+		return typeName
+	}
+	return nested + "." + typeName
+}
+
+// serviceFunctionSignature declares the service function, which takes the
+// request's parameters as a whole when it has any
+func (p Path) serviceFunctionSignature(nested string) string {
+	if len(p.parameters) > 0 {
+		return fmt.Sprintf("%s %s(%s requestParams)", p.qualify(nested, p.unityWebReqPathName()), convention.ClassName(p.operationID), p.qualify(nested, p.requestParamClassName()))
+	}
+	return fmt.Sprintf("%s %s()", p.qualify(nested, p.unityWebReqPathName()), convention.ClassName(p.operationID))
+}
+
+// functionOverideSignature declares the overload of the service function that
+// takes every parameter individually
+func (p Path) functionOverideSignature(nested string) string {
+	return fmt.Sprintf("%s %s(%s)", p.qualify(nested, p.unityWebReqPathName()), convention.ClassName(p.operationID), p.serviceFunctionParameters())
+}
+
+func (p Path) functionOveride(nested string) string {
 	builder := strings.Builder{}
 
-	fmt.Fprintf(&builder, "public %s %s(%s)\n{\n", p.unityWebReqPathName(), convention.ClassName(p.operationID), p.serviceFunctionParameters())
-	fmt.Fprintf(&builder, "\treturn %s(new %s() {\n", convention.ClassName(p.operationID), p.requestParamClassName())
+	fmt.Fprintf(&builder, "public %s\n{\n", p.functionOverideSignature(nested))
+	fmt.Fprintf(&builder, "\treturn %s(new %s() {\n", convention.ClassName(p.operationID), p.qualify(nested, p.requestParamClassName()))
 	// fmt.Fprintf(&builder, "\tvar unityNetworkReq = new UnityWebRequest(%s, %s);\n", p.serviceFunctionNetReqURL(), unity.ToUnityHTTPVerb(p.httpMethod))
 
 	for _, param := range p.parameters {
@@ -360,31 +659,38 @@ func (p Path) functionOveride(knownModifiers []security.Auth) string {
 func (p Path) ServiceFunction(knownModifiers []security.Auth) string {
 	builder := strings.Builder{}
 
+	fmt.Fprintf(&builder, "public %s\n{\n", p.serviceFunctionSignature(""))
 	if len(p.parameters) > 0 {
-		fmt.Fprintf(&builder, "public %s %s(%s requestParams)\n{\n", p.unityWebReqPathName(), convention.ClassName(p.operationID), p.requestParamClassName())
 		builder.WriteString("\tvar unityNetworkReq = requestParams.BuildUnityWebRequest(this.Config.BasePath);\n")
 	} else {
-		fmt.Fprintf(&builder, "public %s %s()\n{\n", p.unityWebReqPathName(), convention.ClassName(p.operationID))
 		fmt.Fprintf(&builder, "\tvar unityNetworkReq = new UnityWebRequest(string.Format(\"{0}%s\", this.Config.BasePath), %s);\n", p.route, unity.ToUnityHTTPVerb(p.httpMethod))
 	}
 
+	builder.WriteString("\tunityNetworkReq.timeout = this.Config.Timeout;\n")
 	if len(p.responses) > 0 {
 		builder.WriteString("\tunityNetworkReq.downloadHandler = new DownloadHandlerBuffer();\n")
 	}
 
-	if len(p.security) == 1 {
-		fmt.Fprintf(&builder, "\t%s\n", p.guard(p.security[0], knownModifiers).ModifyNetworkRequest())
-	} else if len(p.security) > 1 {
-		for _, sec := range p.security {
-			fmt.Fprintf(&builder, "\tif (string.IsNullOrEmpty(this.Config.%s) == false) {\n", convention.TitleCase(sec.Identifier))
-			fmt.Fprintf(&builder, "\t\t%s\n", p.guard(sec, knownModifiers).ModifyNetworkRequest())
-			builder.WriteString("\t}\n")
+	authorizers := ""
+	if p.secured() {
+		// Guards might have to wait on credentials before the request is sent
+		builder.WriteString("\tvar authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();\n")
+		authorizers = ", authorizers.ToArray()"
+		builder.WriteString(p.renderSecurityRequirements(knownModifiers))
+	}
+	if p.Retries() {
+		// Retrying goes back through the service function for a fresh request
+		rebuild := convention.ClassName(p.operationID) + "()"
+		if len(p.parameters) > 0 {
+			rebuild = convention.ClassName(p.operationID) + "(requestParams)"
 		}
+		fmt.Fprintf(&builder, "\treturn new %s(unityNetworkReq%s).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => %s);\n}", p.unityWebReqPathName(), authorizers, rebuild)
+	} else {
+		fmt.Fprintf(&builder, "\treturn new %s(unityNetworkReq%s).InterceptWith(this.Config.Interceptors);\n}", p.unityWebReqPathName(), authorizers)
 	}
-	fmt.Fprintf(&builder, "\treturn new %s(unityNetworkReq);\n}", p.unityWebReqPathName())
 
 	if len(p.parameters) > 0 {
-		fmt.Fprintf(&builder, "\n\n%s", p.functionOveride(knownModifiers))
+		fmt.Fprintf(&builder, "\n\n%s", p.functionOveride(""))
 	}
 
 	return builder.String()
@@ -18,12 +18,13 @@ func Test_PanicsWithMultipleBodyParameters(t *testing.T) {
 			"DevKeyService_GetDevKey",
 			http.MethodGet,
 			[]string{"DevKeyService"},
-			[]path.SecurityMethodReference{path.NewSecurityMethodReference("CognitoAuth")},
+			[]path.SecurityRequirement{{path.NewSecurityMethodReference("CognitoAuth")}},
 			nil,
 			[]path.Parameter{
 				path.NewParameter(path.BodyParameterLocation, "1", true, nil),
 				path.NewParameter(path.BodyParameterLocation, "2", true, nil),
 			},
+			path.PathOptions{},
 		)
 	})
 }
@@ -35,13 +36,14 @@ func Test_SimpleGet(t *testing.T) {
 		"DevKeyService_GetDevKey",
 		http.MethodGet,
 		[]string{"DevKeyService"},
-		[]path.SecurityMethodReference{path.NewSecurityMethodReference("CognitoAuth")},
+		[]path.SecurityRequirement{{path.NewSecurityMethodReference("CognitoAuth")}},
 		nil,
 		nil,
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 	functionCode := route.ServiceFunction([]security.Auth{
 		security.NewAPIKey("CognitoAuth", "X-API-KEY", security.Header),
 	})
@@ -50,14 +52,133 @@ func Test_SimpleGet(t *testing.T) {
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, `public class DevKeyService_GetDevKeyUnityWebRequest : IWebRequest {
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<object, object> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
 
-	public DevKeyService_GetDevKeyUnityWebRequest(UnityWebRequest req) {
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
+
+	public DevKeyService_GetDevKeyUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public DevKeyService_GetDevKeyUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<DevKeyService_GetDevKeyUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public DevKeyService_GetDevKeyUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<DevKeyService_GetDevKeyUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<object, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				DevKeyService_GetDevKeyUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<object, object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<object, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
+	}
+
+	public void Interpret(UnityWebRequest req) {
+		Response = new ApiResponse<object, object>(req, Time.realtimeSinceStartup - sentAt);
 	}
 
 }`, classCode)
@@ -65,8 +186,14 @@ func Test_SimpleGet(t *testing.T) {
 	assert.Equal(t, `public DevKeyService_GetDevKeyUnityWebRequest DevKeyService_GetDevKey()
 {
 	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/dev-keys", this.Config.BasePath), UnityWebRequest.kHttpVerbGET);
-	unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.CognitoAuth);
-	return new DevKeyService_GetDevKeyUnityWebRequest(unityNetworkReq);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (CredentialProviders.Available(this.Config.CognitoAuthCredentials, this.Config.CognitoAuthCredentials.CognitoAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.CognitoAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.CognitoAuthCredentials.CognitoAuth); });
+	} else {
+		throw new System.InvalidOperationException("unable to authorize DevKeyService_GetDevKey, the config has no credentials for CognitoAuth");
+	}
+	return new DevKeyService_GetDevKeyUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => DevKeyService_GetDevKey());
 }`, functionCode)
 
 	assert.Equal(t, "", requestParamsCode)
@@ -79,9 +206,9 @@ func Test_ParameterInPath(t *testing.T) {
 		"UserService_GetUser",
 		http.MethodGet,
 		[]string{"UserService"},
-		[]path.SecurityMethodReference{
-			path.NewSecurityMethodReference("CognitoAuth"),
-			path.NewSecurityMethodReference("DevKeyAuth"),
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("CognitoAuth")},
+			{path.NewSecurityMethodReference("DevKeyAuth")},
 		},
 		map[string]path.Response{
 			"200":     path.NewDefinitionResponse("A successful response.", model.NewDefinitionReference("#/definitions/v1UserResponse")),
@@ -90,10 +217,11 @@ func Test_ParameterInPath(t *testing.T) {
 		[]path.Parameter{
 			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 	functionCode := route.ServiceFunction([]security.Auth{
 		security.NewAPIKey("CognitoAuth", "CognitoThing", security.Header),
 		security.NewAPIKey("DevKeyAuth", "X-API-KEY", security.Header),
@@ -108,22 +236,150 @@ func Test_ParameterInPath(t *testing.T) {
 	// An unexpected error response
 	public RuntimeError fallbackResponse;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<V1UserResponse, RuntimeError> Response { get; private set; }
 
-	public UserService_GetUserUnityWebRequest(UnityWebRequest req) {
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
+
+	public UserService_GetUserUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public UserService_GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<UserService_GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public UserService_GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<UserService_GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<V1UserResponse, RuntimeError>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				UserService_GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<V1UserResponse, RuntimeError>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<V1UserResponse, RuntimeError>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		if (req.responseCode == 200) {
-			success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
-		} else {
-			fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+		success = default(V1UserResponse);
+		fallbackResponse = default(RuntimeError);
+		Response = new ApiResponse<V1UserResponse, RuntimeError>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {
+				success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+				Response.Success = success;
+			} else {
+				fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+				if (req.responseCode < 200 || req.responseCode >= 300) {
+					Response.Error = fallbackResponse;
+				}
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
 		}
 	}
 
@@ -132,14 +388,17 @@ func Test_ParameterInPath(t *testing.T) {
 	assert.Equal(t, `public UserService_GetUserUnityWebRequest UserService_GetUser(UserService_GetUserRequestParams requestParams)
 {
 	var unityNetworkReq = requestParams.BuildUnityWebRequest(this.Config.BasePath);
+	unityNetworkReq.timeout = this.Config.Timeout;
 	unityNetworkReq.downloadHandler = new DownloadHandlerBuffer();
-	if (string.IsNullOrEmpty(this.Config.CognitoAuth) == false) {
-		unityNetworkReq.SetRequestHeader("CognitoThing", this.Config.CognitoAuth);
-	}
-	if (string.IsNullOrEmpty(this.Config.DevKeyAuth) == false) {
-		unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.DevKeyAuth);
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (CredentialProviders.Available(this.Config.CognitoAuthCredentials, this.Config.CognitoAuthCredentials.CognitoAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.CognitoAuthCredentials, () => { unityNetworkReq.SetRequestHeader("CognitoThing", this.Config.CognitoAuthCredentials.CognitoAuth); });
+	} else if (CredentialProviders.Available(this.Config.DevKeyAuthCredentials, this.Config.DevKeyAuthCredentials.DevKeyAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.DevKeyAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.DevKeyAuthCredentials.DevKeyAuth); });
+	} else {
+		throw new System.InvalidOperationException("unable to authorize UserService_GetUser, the config has no credentials for CognitoAuth or DevKeyAuth");
 	}
-	return new UserService_GetUserUnityWebRequest(unityNetworkReq);
+	return new UserService_GetUserUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => UserService_GetUser(requestParams));
 }
 
 public UserService_GetUserUnityWebRequest UserService_GetUser(string userId)
@@ -157,9 +416,9 @@ func Test_AcknowledgesSingleResponses(t *testing.T) {
 		"UserService_GetUser",
 		http.MethodGet,
 		[]string{"UserService"},
-		[]path.SecurityMethodReference{
-			path.NewSecurityMethodReference("CognitoAuth"),
-			path.NewSecurityMethodReference("DevKeyAuth"),
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("CognitoAuth")},
+			{path.NewSecurityMethodReference("DevKeyAuth")},
 		},
 		map[string]path.Response{
 			"200": path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/v1UserResponse")),
@@ -167,30 +426,155 @@ func Test_AcknowledgesSingleResponses(t *testing.T) {
 		[]path.Parameter{
 			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, `public class UserService_GetUserUnityWebRequest : IWebRequest {
 
 	public V1UserResponse success;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<V1UserResponse, object> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
 
-	public UserService_GetUserUnityWebRequest(UnityWebRequest req) {
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
+
+	public UserService_GetUserUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public UserService_GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<UserService_GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public UserService_GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<UserService_GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				UserService_GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<V1UserResponse, object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		if (req.responseCode == 200) {
-			success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+		success = default(V1UserResponse);
+		Response = new ApiResponse<V1UserResponse, object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {
+				success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+				Response.Success = success;
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
 		}
 	}
 
@@ -204,9 +588,9 @@ func Test_AcknowledgesDefaultResponses(t *testing.T) {
 		"UserService_GetUser",
 		http.MethodGet,
 		[]string{"UserService"},
-		[]path.SecurityMethodReference{
-			path.NewSecurityMethodReference("CognitoAuth"),
-			path.NewSecurityMethodReference("DevKeyAuth"),
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("CognitoAuth")},
+			{path.NewSecurityMethodReference("DevKeyAuth")},
 		},
 		map[string]path.Response{
 			"default": path.NewDefinitionResponse("An unexpected error response", model.NewDefinitionReference("#/definitions/runtimeError")),
@@ -214,10 +598,11 @@ func Test_AcknowledgesDefaultResponses(t *testing.T) {
 		[]path.Parameter{
 			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, `public class UserService_GetUserUnityWebRequest : IWebRequest {
@@ -225,19 +610,147 @@ func Test_AcknowledgesDefaultResponses(t *testing.T) {
 	// An unexpected error response
 	public RuntimeError fallbackResponse;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<object, RuntimeError> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
 
-	public UserService_GetUserUnityWebRequest(UnityWebRequest req) {
+	public UserService_GetUserUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public UserService_GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<UserService_GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public UserService_GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<UserService_GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<object, RuntimeError>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				UserService_GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<object, RuntimeError>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<object, RuntimeError>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+		fallbackResponse = default(RuntimeError);
+		Response = new ApiResponse<object, RuntimeError>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+			if (req.responseCode >= 200 && req.responseCode < 300) {
+				Response.Success = fallbackResponse;
+			} else {
+				Response.Error = fallbackResponse;
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
+		}
 	}
 
 }`, classCode)
@@ -250,9 +763,9 @@ func Test_ThreeParametersInPath(t *testing.T) {
 		"UserService_GetUser",
 		http.MethodGet,
 		[]string{"UserService"},
-		[]path.SecurityMethodReference{
-			path.NewSecurityMethodReference("CognitoAuth"),
-			path.NewSecurityMethodReference("DevKeyAuth"),
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("CognitoAuth")},
+			{path.NewSecurityMethodReference("DevKeyAuth")},
 		},
 		map[string]path.Response{
 			"200":     path.NewDefinitionResponse("A successful response.", model.NewDefinitionReference("#/definitions/v1UserResponse")),
@@ -262,10 +775,11 @@ func Test_ThreeParametersInPath(t *testing.T) {
 		[]path.Parameter{
 			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, `public class UserService_GetUserUnityWebRequest : IWebRequest {
@@ -279,24 +793,154 @@ func Test_ThreeParametersInPath(t *testing.T) {
 	// An unexpected error response
 	public RuntimeError fallbackResponse;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<V1UserResponse, object> Response { get; private set; }
 
-	public UserService_GetUserUnityWebRequest(UnityWebRequest req) {
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
+
+	public UserService_GetUserUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public UserService_GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<UserService_GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public UserService_GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<UserService_GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				UserService_GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<V1UserResponse, object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		if (req.responseCode == 200) {
-			success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
-		} else if (req.responseCode == 401) {
-			unauthorized = JsonConvert.DeserializeObject<V1Unauthorized>(req.downloadHandler.text);
-		} else {
-			fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+		success = default(V1UserResponse);
+		unauthorized = default(V1Unauthorized);
+		fallbackResponse = default(RuntimeError);
+		Response = new ApiResponse<V1UserResponse, object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {
+				success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+				Response.Success = success;
+			} else if (req.responseCode == 401) {
+				unauthorized = JsonConvert.DeserializeObject<V1Unauthorized>(req.downloadHandler.text);
+				Response.Error = unauthorized;
+			} else {
+				fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+				if (req.responseCode < 200 || req.responseCode >= 300) {
+					Response.Error = fallbackResponse;
+				}
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
 		}
 	}
 
@@ -309,9 +953,9 @@ func Test_HandlesNilResponseDefinitions(t *testing.T) {
 	opID := "UserService_GetUser"
 	urlRotue := "/api/v1/users/{userId}"
 	method := http.MethodGet
-	security := []path.SecurityMethodReference{
-		path.NewSecurityMethodReference("CognitoAuth"),
-		path.NewSecurityMethodReference("DevKeyAuth"),
+	security := []path.SecurityRequirement{
+		{path.NewSecurityMethodReference("CognitoAuth")},
+		{path.NewSecurityMethodReference("DevKeyAuth")},
 	}
 	tags := []string{"UserService"}
 
@@ -328,17 +972,18 @@ func Test_HandlesNilResponseDefinitions(t *testing.T) {
 			"default": path.NewDefinitionResponse("An unexpected error response", model.NewDefinitionReference("#/definitions/runtimeError")),
 		},
 		params,
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
-	classCode := route.UnityWebRequest()
+	classCode := route.UnityWebRequest(false)
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, params, route.Parameters())
 	assert.Equal(t, urlRotue, route.Route())
 	assert.Equal(t, opID, route.OperationID())
 	assert.Equal(t, method, route.Method())
-	assert.Equal(t, security, route.SecurityReferences())
+	assert.Equal(t, security, route.SecurityRequirements())
 	assert.Equal(t, tags, route.Tags())
 	assert.Equal(t, `public class UserService_GetUserUnityWebRequest : IWebRequest {
 
@@ -351,26 +996,156 @@ func Test_HandlesNilResponseDefinitions(t *testing.T) {
 	// An unexpected error response
 	public RuntimeError fallbackResponse;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<V1UserResponse, object> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
 
-	public UserService_GetUserUnityWebRequest(UnityWebRequest req) {
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	private IRequestAuthorizer[] authorizers;
+
+	public UserService_GetUserUnityWebRequest(UnityWebRequest req, params IRequestAuthorizer[] authorizers) {
 		this.UnderlyingRequest = req;
+		this.authorizers = authorizers;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public UserService_GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<UserService_GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public UserService_GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<UserService_GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				foreach (var authorizer in this.authorizers) {
+					yield return authorizer.Authorize(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				foreach (var authorizer in this.authorizers) {
+					authorizer.Inspect(this.UnderlyingRequest);
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				UserService_GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<V1UserResponse, object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+				this.authorizers = retry.authorizers;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		if (req.responseCode == 200) {
-			success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
-		} else if (req.responseCode == 401) {
-			// No expected response. Do nothing!
-		} else if (req.responseCode == 501) {
-			notImplemented = req.downloadHandler.data;
-		} else {
-			fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+		success = default(V1UserResponse);
+		notImplemented = default(byte[]);
+		fallbackResponse = default(RuntimeError);
+		Response = new ApiResponse<V1UserResponse, object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {
+				success = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+				Response.Success = success;
+			} else if (req.responseCode == 401) {
+				// No expected response. Do nothing!
+			} else if (req.responseCode == 501) {
+				notImplemented = req.downloadHandler.data;
+				Response.Error = notImplemented;
+			} else {
+				fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+				if (req.responseCode < 200 || req.responseCode >= 300) {
+					Response.Error = fallbackResponse;
+				}
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
 		}
 	}
 
@@ -392,6 +1167,7 @@ func Test_DealsWithQueryParams(t *testing.T) {
 			path.NewParameter(path.PathParameterLocation, "userId", true, property.NewString("userId", "")),
 			path.NewParameter(path.QueryParameterLocation, "diffId", true, property.NewString("diffId", "")),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
@@ -401,8 +1177,9 @@ func Test_DealsWithQueryParams(t *testing.T) {
 	assert.Equal(t, `public UserService_GetUserUnityWebRequest UserService_GetUser(UserService_GetUserRequestParams requestParams)
 {
 	var unityNetworkReq = requestParams.BuildUnityWebRequest(this.Config.BasePath);
+	unityNetworkReq.timeout = this.Config.Timeout;
 	unityNetworkReq.downloadHandler = new DownloadHandlerBuffer();
-	return new UserService_GetUserUnityWebRequest(unityNetworkReq);
+	return new UserService_GetUserUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => UserService_GetUser(requestParams));
 }
 
 public UserService_GetUserUnityWebRequest UserService_GetUser(string userId, string diffId)
@@ -434,18 +1211,20 @@ func Test_DealsWithMultipleQueryParamsAndBody(t *testing.T) {
 			path.NewParameter(path.QueryParameterLocation, "another-id", true, property.NewInteger("another-id", "")),
 			path.NewParameter(path.BodyParameterLocation, "query", true, property.NewDefinitionReference("test", query)),
 		},
+		path.PathOptions{},
 	)
 
 	// ********************************** ACT *********************************
 	functionCode := route.ServiceFunction(nil)
-	supportingClasses := route.SupportingClasses()
+	supportingClasses := route.SupportingClasses(false)
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, `public GetUserUnityWebRequest GetUser(GetUserRequestParams requestParams)
 {
 	var unityNetworkReq = requestParams.BuildUnityWebRequest(this.Config.BasePath);
+	unityNetworkReq.timeout = this.Config.Timeout;
 	unityNetworkReq.downloadHandler = new DownloadHandlerBuffer();
-	return new GetUserUnityWebRequest(unityNetworkReq);
+	return new GetUserUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => GetUser(requestParams));
 }
 
 public GetUserUnityWebRequest GetUser(string userId, string userName, string diffId, int anotherId, Query query)
@@ -464,19 +1243,137 @@ public GetUserUnityWebRequest GetUser(string userId, string userName, string dif
 	// An unexpected error response
 	public RuntimeError fallbackResponse;
 
-	public UnityWebRequest UnderlyingRequest{ get; }
+	public ApiResponse<object, RuntimeError> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
 
 	public GetUserUnityWebRequest(UnityWebRequest req) {
 		this.UnderlyingRequest = req;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public GetUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<GetUserUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public GetUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<GetUserUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
 	}
 
 	public IEnumerator Run() {
-		yield return this.UnderlyingRequest.SendWebRequest();
-		Interpret(this.UnderlyingRequest);
+		try {
+			while (true) {
+				Attempts++;
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<object, RuntimeError>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				GetUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<object, RuntimeError>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<object, RuntimeError>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
 	}
 
 	public void Interpret(UnityWebRequest req) {
-		fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+		fallbackResponse = default(RuntimeError);
+		Response = new ApiResponse<object, RuntimeError>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+			if (req.responseCode >= 200 && req.responseCode < 300) {
+				Response.Success = fallbackResponse;
+			} else {
+				Response.Error = fallbackResponse;
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
+		}
 	}
 
 }
@@ -534,3 +1431,511 @@ public class GetUserRequestParams
 	}
 }`, supportingClasses)
 }
+
+func Test_HandlesAllStatusCodesAndRanges(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users",
+		"createUser",
+		http.MethodPost,
+		[]string{"UserService"},
+		nil,
+		map[string]path.Response{
+			"default": path.NewDefinitionResponse("An unexpected error response", model.NewDefinitionReference("#/definitions/runtimeError")),
+			"4XX":     path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/clientError")),
+			"2xx":     nil,
+			"201":     path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/v1UserResponse")),
+			"204":     nil,
+			"409":     path.NewStringResponse("", true),
+			"418":     path.NewStringResponse("", true),
+			"422":     path.NewDefinitionResponse("", model.NewDefinitionReference("#/definitions/validationError")),
+			"429":     nil,
+		},
+		nil,
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	classCode := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public class CreateUserUnityWebRequest : IWebRequest {
+
+	public V1UserResponse created;
+
+	public string conflict;
+
+	public string status418;
+
+	public ValidationError unprocessableEntity;
+
+	public ClientError clientError;
+
+	// An unexpected error response
+	public RuntimeError fallbackResponse;
+
+	public ApiResponse<V1UserResponse, object> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; }
+
+	private float sentAt;
+
+	public CreateUserUnityWebRequest(UnityWebRequest req) {
+		this.UnderlyingRequest = req;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public CreateUserUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	public IEnumerator Run() {
+		try {
+			if (this.interceptors != null) {
+				yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+			}
+			if (cancelled) {
+				yield break;
+			}
+			sentAt = Time.realtimeSinceStartup;
+			yield return this.UnderlyingRequest.SendWebRequest();
+			if (cancelled) {
+				yield break;
+			}
+			if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+				Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+				yield break;
+			}
+			Interpret(this.UnderlyingRequest);
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<V1UserResponse, object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
+	}
+
+	public void Interpret(UnityWebRequest req) {
+		Response = new ApiResponse<V1UserResponse, object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 201) {
+				created = JsonConvert.DeserializeObject<V1UserResponse>(req.downloadHandler.text);
+				Response.Success = created;
+			} else if (req.responseCode == 204) {
+				// No expected response. Do nothing!
+			} else if (req.responseCode == 409) {
+				conflict = req.downloadHandler.text;
+				Response.Error = conflict;
+			} else if (req.responseCode == 418) {
+				status418 = req.downloadHandler.text;
+				Response.Error = status418;
+			} else if (req.responseCode == 422) {
+				unprocessableEntity = JsonConvert.DeserializeObject<ValidationError>(req.downloadHandler.text);
+				Response.Error = unprocessableEntity;
+			} else if (req.responseCode == 429) {
+				// No expected response. Do nothing!
+			} else if (req.responseCode >= 200 && req.responseCode < 300) {
+				// No expected response. Do nothing!
+			} else if (req.responseCode >= 400 && req.responseCode < 500) {
+				clientError = JsonConvert.DeserializeObject<ClientError>(req.downloadHandler.text);
+				Response.Error = clientError;
+			} else {
+				fallbackResponse = JsonConvert.DeserializeObject<RuntimeError>(req.downloadHandler.text);
+				if (req.responseCode < 200 || req.responseCode >= 300) {
+					Response.Error = fallbackResponse;
+				}
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
+		}
+	}
+
+}`, classCode)
+}
+
+func Test_ValidResponseKeys(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		valid   bool
+		isRange bool
+	}{
+		"ok":              {input: "200", valid: true},
+		"teapot":          {input: "418", valid: true},
+		"range":           {input: "2XX", valid: true, isRange: true},
+		"lowercase range": {input: "5xx", valid: true, isRange: true},
+		"default":         {input: "default", valid: true},
+		"out of bounds":   {input: "600", valid: false},
+		"bad range":       {input: "6XX", valid: false},
+		"gibberish":       {input: "success", valid: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.valid, path.IsValidResponseKey(tc.input))
+			assert.Equal(t, tc.isRange, path.IsStatusCodeRange(tc.input))
+		})
+	}
+}
+
+func Test_ReadsResponseHeaders(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users",
+		"listUsers",
+		http.MethodGet,
+		[]string{"UserService"},
+		nil,
+		map[string]path.Response{
+			"200": path.NewArrayResponse("", property.NewArray("", property.NewString("", ""))),
+			"429": nil,
+		},
+		nil,
+		path.PathOptions{
+			ResponseHeaders: map[string][]path.ResponseHeader{
+				"200": {
+					path.NewResponseHeader("X-RateLimit-Remaining", "Requests left in the window", property.NewInteger("X-RateLimit-Remaining", "")),
+					path.NewResponseHeader("X-Next-Cursor", "", property.NewString("X-Next-Cursor", "")),
+				},
+				"429": {
+					path.NewResponseHeader("X-RateLimit-Remaining", "Requests left in the window", property.NewInteger("X-RateLimit-Remaining", "")),
+				},
+			},
+		},
+	)
+
+	// ********************************** ACT *********************************
+	classCode := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Len(t, route.ResponseHeaders(), 2)
+	assert.Equal(t, `public class ListUsersUnityWebRequest : IWebRequest {
+
+	public string[] success;
+
+	public string XNextCursor { get; private set; }
+
+	// Requests left in the window
+	public int? XRateLimitRemaining { get; private set; }
+
+	public ApiResponse<string[], object> Response { get; private set; }
+
+	IApiResponse IWebRequest.Response { get { return Response; } }
+
+	public UnityWebRequest UnderlyingRequest{ get; private set; }
+
+	private float sentAt;
+
+	public ListUsersUnityWebRequest(UnityWebRequest req) {
+		this.UnderlyingRequest = req;
+		this.DisposeOnComplete = true;
+	}
+
+	private RequestInterceptors interceptors;
+
+	// InterceptWith runs the request and its response through the interceptors
+	public ListUsersUnityWebRequest InterceptWith(RequestInterceptors interceptors) {
+		this.interceptors = interceptors;
+		return this;
+	}
+
+	// Whether or not the underlying request is disposed once Run is done. Turn
+	// it off to keep reading from it afterwards, and dispose of it yourself.
+	public bool DisposeOnComplete { get; set; }
+
+	private bool cancelled;
+
+	private bool disposed;
+
+	// Abort stops the request, ending Run with a cancelled result
+	public void Abort() {
+		cancelled = true;
+		if (disposed == false) {
+			this.UnderlyingRequest.Abort();
+		}
+	}
+
+	// Dispose frees the underlying request along with its upload and download
+	// handlers
+	public void Dispose() {
+		if (disposed) {
+			return;
+		}
+		disposed = true;
+		this.UnderlyingRequest.Dispose();
+	}
+
+	// How many times the request has been sent
+	public int Attempts { get; private set; }
+
+	// What decides whether or not the request is sent again
+	public RetryPolicy RetryPolicy { get; private set; }
+
+	private System.Func<ListUsersUnityWebRequest> rebuild;
+
+	// RetryWith has the request rebuilt and sent again whenever the policy says
+	// it's worth retrying
+	public ListUsersUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<ListUsersUnityWebRequest> rebuild) {
+		this.RetryPolicy = retryPolicy;
+		this.rebuild = rebuild;
+		return this;
+	}
+
+	public IEnumerator Run() {
+		try {
+			while (true) {
+				Attempts++;
+				if (this.interceptors != null) {
+					yield return this.interceptors.BeforeSend(this.UnderlyingRequest);
+				}
+				if (cancelled) {
+					yield break;
+				}
+				sentAt = Time.realtimeSinceStartup;
+				yield return this.UnderlyingRequest.SendWebRequest();
+				if (cancelled) {
+					yield break;
+				}
+				if (this.interceptors != null && this.interceptors.AfterReceive(this.UnderlyingRequest) == false) {
+					Response = new ApiResponse<string[], object>(this.UnderlyingRequest, Time.realtimeSinceStartup - sentAt);
+					yield break;
+				}
+				Interpret(this.UnderlyingRequest);
+				if (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {
+					yield break;
+				}
+				yield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));
+				if (cancelled) {
+					yield break;
+				}
+
+				// Unity requests can only be sent once
+				ListUsersUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<string[], object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+				this.UnderlyingRequest = retry.UnderlyingRequest;
+			}
+		} finally {
+			if (cancelled) {
+				Response = new ApiResponse<string[], object>(this.UnderlyingRequest);
+				Response.Cancelled = true;
+			}
+			if (DisposeOnComplete) {
+				Dispose();
+			}
+		}
+	}
+
+	public void Interpret(UnityWebRequest req) {
+		success = default(string[]);
+		XNextCursor = default(string);
+		XRateLimitRemaining = default(int?);
+		Response = new ApiResponse<string[], object>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {
+				success = JsonConvert.DeserializeObject<string[]>(req.downloadHandler.text);
+				Response.Success = success;
+			} else if (req.responseCode == 429) {
+				// No expected response. Do nothing!
+			}
+		} catch (System.Exception e) {
+			Response.ParseException = e;
+		}
+		var xNextCursorHeader = req.GetResponseHeader("X-Next-Cursor");
+		if (xNextCursorHeader != null) {
+			XNextCursor = xNextCursorHeader;
+		}
+		var xRateLimitRemainingHeader = req.GetResponseHeader("X-RateLimit-Remaining");
+		if (xRateLimitRemainingHeader != null) {
+			int parsedXRateLimitRemaining;
+			if (int.TryParse(xRateLimitRemainingHeader, System.Globalization.NumberStyles.Integer, System.Globalization.CultureInfo.InvariantCulture, out parsedXRateLimitRemaining)) {
+				XRateLimitRemaining = parsedXRateLimitRemaining;
+			}
+		}
+	}
+
+}`, classCode)
+}
+
+func Test_OAuth2GuardedServiceFunction(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/recordings",
+		"ListRecordings",
+		http.MethodGet,
+		[]string{"RecordingService"},
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("DevKeyAuth")},
+			{path.NewSecurityMethodReference("PlayerAuth")},
+		},
+		nil,
+		nil,
+		path.PathOptions{},
+	)
+	knownModifiers := []security.Auth{
+		security.NewAPIKey("DevKeyAuth", "X-API-KEY", security.Header),
+		security.NewOAuth2("PlayerAuth", []security.OAuth2Flow{
+			security.NewOAuth2Flow(security.PasswordFlow, "", "https://example.com/token", "", nil),
+		}),
+	}
+
+	// ********************************** ACT *********************************
+	functionCode := route.ServiceFunction(knownModifiers)
+	onlyOAuthCode := path.NewPath(
+		"/api/v1/recordings",
+		"ListRecordings",
+		http.MethodGet,
+		[]string{"RecordingService"},
+		[]path.SecurityRequirement{{path.NewSecurityMethodReference("PlayerAuth")}},
+		nil,
+		nil,
+		path.PathOptions{},
+	).ServiceFunction(knownModifiers)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public ListRecordingsUnityWebRequest ListRecordings()
+{
+	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/recordings", this.Config.BasePath), UnityWebRequest.kHttpVerbGET);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (CredentialProviders.Available(this.Config.DevKeyAuthCredentials, this.Config.DevKeyAuthCredentials.DevKeyAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.DevKeyAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.DevKeyAuthCredentials.DevKeyAuth); });
+	} else if (this.Config.PlayerAuthTokens.CanAuthorize) {
+		this.Config.PlayerAuthTokens.ApplyToken(unityNetworkReq);
+		authorizers.Add(this.Config.PlayerAuthTokens);
+	} else {
+		throw new System.InvalidOperationException("unable to authorize ListRecordings, the config has no credentials for DevKeyAuth or PlayerAuth");
+	}
+	return new ListRecordingsUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => ListRecordings());
+}`, functionCode)
+
+	assert.Equal(t, `public ListRecordingsUnityWebRequest ListRecordings()
+{
+	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/recordings", this.Config.BasePath), UnityWebRequest.kHttpVerbGET);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (this.Config.PlayerAuthTokens.CanAuthorize) {
+		this.Config.PlayerAuthTokens.ApplyToken(unityNetworkReq);
+		authorizers.Add(this.Config.PlayerAuthTokens);
+	} else {
+		throw new System.InvalidOperationException("unable to authorize ListRecordings, the config has no credentials for PlayerAuth");
+	}
+	return new ListRecordingsUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => ListRecordings());
+}`, onlyOAuthCode)
+}
+
+func Test_SecurityRequirementsAreAlternativesOfMethodsRequiredTogether(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/recordings",
+		"CreateRecording",
+		http.MethodPost,
+		[]string{"RecordingService"},
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("CognitoAuth"), path.NewSecurityMethodReference("DevKeyAuth")},
+			{path.NewSecurityMethodReference("AdminAuth")},
+		},
+		nil,
+		nil,
+		path.PathOptions{},
+	)
+	knownModifiers := []security.Auth{
+		security.NewBasicAuth("AdminAuth"),
+		security.NewAPIKey("CognitoAuth", "X-COGNITO", security.Header),
+		security.NewAPIKey("DevKeyAuth", "X-API-KEY", security.Header),
+	}
+
+	anonymousRoute := path.NewPath(
+		"/api/v1/recordings",
+		"ListRecordings",
+		http.MethodGet,
+		nil,
+		[]path.SecurityRequirement{
+			{path.NewSecurityMethodReference("DevKeyAuth")},
+			{},
+		},
+		nil,
+		nil,
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	functionCode := route.ServiceFunction(knownModifiers)
+	anonymousFunctionCode := anonymousRoute.ServiceFunction(knownModifiers)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `public CreateRecordingUnityWebRequest CreateRecording()
+{
+	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/recordings", this.Config.BasePath), UnityWebRequest.kHttpVerbPOST);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (CredentialProviders.Available(this.Config.CognitoAuthCredentials, this.Config.CognitoAuthCredentials.CognitoAuth) && CredentialProviders.Available(this.Config.DevKeyAuthCredentials, this.Config.DevKeyAuthCredentials.DevKeyAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.CognitoAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-COGNITO", this.Config.CognitoAuthCredentials.CognitoAuth); });
+		CredentialProviders.Apply(authorizers, this.Config.DevKeyAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.DevKeyAuthCredentials.DevKeyAuth); });
+	} else if (CredentialProviders.Available(this.Config.AdminAuthCredentials, this.Config.AdminAuthCredentials.AdminAuthUsername)) {
+		CredentialProviders.Apply(authorizers, this.Config.AdminAuthCredentials, () => { unityNetworkReq.SetRequestHeader("Authorization", "Basic " + System.Convert.ToBase64String(Encoding.UTF8.GetBytes(this.Config.AdminAuthCredentials.AdminAuthUsername + ":" + this.Config.AdminAuthCredentials.AdminAuthPassword))); });
+	} else {
+		throw new System.InvalidOperationException("unable to authorize CreateRecording, the config has no credentials for (CognitoAuth and DevKeyAuth) or AdminAuth");
+	}
+	return new CreateRecordingUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors);
+}`, functionCode)
+
+	assert.Equal(t, `public ListRecordingsUnityWebRequest ListRecordings()
+{
+	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/recordings", this.Config.BasePath), UnityWebRequest.kHttpVerbGET);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	var authorizers = new System.Collections.Generic.List<IRequestAuthorizer>();
+	if (CredentialProviders.Available(this.Config.DevKeyAuthCredentials, this.Config.DevKeyAuthCredentials.DevKeyAuth)) {
+		CredentialProviders.Apply(authorizers, this.Config.DevKeyAuthCredentials, () => { unityNetworkReq.SetRequestHeader("X-API-KEY", this.Config.DevKeyAuthCredentials.DevKeyAuth); });
+	}
+	return new ListRecordingsUnityWebRequest(unityNetworkReq, authorizers.ToArray()).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => ListRecordings());
+}`, anonymousFunctionCode)
+	assert.Len(t, anonymousRoute.SecurityReferences(), 1)
+}
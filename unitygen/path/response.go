@@ -6,3 +6,9 @@ type Response interface {
 	Interpret(variableName string, downloadHandlerName string) string
 	VariableType() string
 }
+
+// plainTextBody is the body of a response holding a single plain text value,
+// without the whitespace and newlines servers tend to send along with it
+func plainTextBody(downloadHandlerName string) string {
+	return downloadHandlerName + ".text.Trim()"
+}
@@ -0,0 +1,111 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+)
+
+// ResponseHeader is a value the server sends back inside the headers of a
+// response (ie: X-RateLimit-Remaining, Location, ETag)
+type ResponseHeader struct {
+	name        string
+	description string
+	headerType  model.Property
+}
+
+// NewResponseHeader creates a new response header
+func NewResponseHeader(name, description string, headerType model.Property) ResponseHeader {
+	return ResponseHeader{
+		name:        name,
+		description: description,
+		headerType:  headerType,
+	}
+}
+
+// Name of the header as it appears in the HTTP response
+func (rh ResponseHeader) Name() string {
+	return rh.name
+}
+
+// Description of the header
+func (rh ResponseHeader) Description() string {
+	return rh.description
+}
+
+// Schema is what type of value the header contains
+func (rh ResponseHeader) Schema() model.Property {
+	return rh.headerType
+}
+
+func (rh ResponseHeader) propertyName() string {
+	return convention.TitleCase(rh.name)
+}
+
+func (rh ResponseHeader) rawVariableName() string {
+	return convention.CamelCase(rh.name) + "Header"
+}
+
+// VariableType is the C# type the header gets converted to. Value types are
+// made nullable so a missing header can be told apart from a zero value.
+func (rh ResponseHeader) VariableType() string {
+	if rh.headerType == nil {
+		return "string"
+	}
+	switch varType := rh.headerType.ToVariableType(); varType {
+	case "int", "long", "float", "double", "bool", "System.DateTime":
+		return varType + "?"
+	case "string[]":
+		return varType
+	}
+	return "string"
+}
+
+func (rh ResponseHeader) parseStatement() string {
+	parse := "%s.TryParse(%s, System.Globalization.NumberStyles.Float, System.Globalization.CultureInfo.InvariantCulture, out parsed%s)"
+	switch rh.VariableType() {
+	case "int?", "long?":
+		parse = "%s.TryParse(%s, System.Globalization.NumberStyles.Integer, System.Globalization.CultureInfo.InvariantCulture, out parsed%s)"
+	case "bool?":
+		parse = "%s.TryParse(%s, out parsed%s)"
+	case "System.DateTime?":
+		parse = "%s.TryParse(%s, System.Globalization.CultureInfo.InvariantCulture, System.Globalization.DateTimeStyles.RoundtripKind, out parsed%s)"
+	}
+	return fmt.Sprintf(parse, strings.TrimSuffix(rh.VariableType(), "?"), rh.rawVariableName(), rh.propertyName())
+}
+
+// ClassVariables is the property found on the web request for accessing the
+// converted header
+func (rh ResponseHeader) ClassVariables() string {
+	builder := strings.Builder{}
+	for _, line := range strings.Split(strings.TrimSpace(rh.description), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(&builder, "\t// %s\n", line)
+		}
+	}
+	fmt.Fprintf(&builder, "\tpublic %s %s { get; private set; }\n", rh.VariableType(), rh.propertyName())
+	return builder.String()
+}
+
+// Interpret reads the header from the request and converts it to it's
+// appropriate type
+func (rh ResponseHeader) Interpret(requestVariableName string) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "\t\tvar %s = %s.GetResponseHeader(\"%s\");\n", rh.rawVariableName(), requestVariableName, rh.name)
+	fmt.Fprintf(&builder, "\t\tif (%s != null) {\n", rh.rawVariableName())
+	switch rh.VariableType() {
+	case "string":
+		fmt.Fprintf(&builder, "\t\t\t%s = %s;\n", rh.propertyName(), rh.rawVariableName())
+	case "string[]":
+		fmt.Fprintf(&builder, "\t\t\t%s = System.Array.ConvertAll(%s.Split(','), s => s.Trim());\n", rh.propertyName(), rh.rawVariableName())
+	default:
+		fmt.Fprintf(&builder, "\t\t\t%s parsed%s;\n", strings.TrimSuffix(rh.VariableType(), "?"), rh.propertyName())
+		fmt.Fprintf(&builder, "\t\t\tif (%s) {\n", rh.parseStatement())
+		fmt.Fprintf(&builder, "\t\t\t\t%s = parsed%s;\n", rh.propertyName(), rh.propertyName())
+		builder.WriteString("\t\t\t}\n")
+	}
+	builder.WriteString("\t\t}\n")
+	return builder.String()
+}
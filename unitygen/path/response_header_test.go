@@ -0,0 +1,114 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ResponseHeader(t *testing.T) {
+	// ARRANGE ================================================================
+	header := path.NewResponseHeader("X-RateLimit-Remaining", "Requests left\nin the window", property.NewInteger("X-RateLimit-Remaining", "int32"))
+
+	// ACT ====================================================================
+	classVars := header.ClassVariables()
+	interpret := header.Interpret("req")
+
+	// ASSERT =================================================================
+	assert.Equal(t, "X-RateLimit-Remaining", header.Name())
+	assert.Equal(t, "Requests left\nin the window", header.Description())
+	assert.Equal(t, "int?", header.VariableType())
+	assert.Equal(t, `	// Requests left
+	// in the window
+	public int? XRateLimitRemaining { get; private set; }
+`, classVars)
+	assert.Equal(t, `		var xRateLimitRemainingHeader = req.GetResponseHeader("X-RateLimit-Remaining");
+		if (xRateLimitRemainingHeader != null) {
+			int parsedXRateLimitRemaining;
+			if (int.TryParse(xRateLimitRemainingHeader, System.Globalization.NumberStyles.Integer, System.Globalization.CultureInfo.InvariantCulture, out parsedXRateLimitRemaining)) {
+				XRateLimitRemaining = parsedXRateLimitRemaining;
+			}
+		}
+`, interpret)
+}
+
+func Test_ResponseHeader_Conversions(t *testing.T) {
+	tests := map[string]struct {
+		header    path.ResponseHeader
+		varType   string
+		interpret string
+	}{
+		"string": {
+			header:  path.NewResponseHeader("ETag", "", property.NewString("ETag", "")),
+			varType: "string",
+			interpret: `		var eTagHeader = req.GetResponseHeader("ETag");
+		if (eTagHeader != null) {
+			ETag = eTagHeader;
+		}
+`,
+		},
+		"string array": {
+			header:  path.NewResponseHeader("Allow", "", property.NewArray("Allow", property.NewString("Allow", ""))),
+			varType: "string[]",
+			interpret: `		var allowHeader = req.GetResponseHeader("Allow");
+		if (allowHeader != null) {
+			Allow = System.Array.ConvertAll(allowHeader.Split(','), s => s.Trim());
+		}
+`,
+		},
+		"number": {
+			header:  path.NewResponseHeader("X-Cost", "", property.NewNumber("X-Cost", "")),
+			varType: "float?",
+			interpret: `		var xCostHeader = req.GetResponseHeader("X-Cost");
+		if (xCostHeader != null) {
+			float parsedXCost;
+			if (float.TryParse(xCostHeader, System.Globalization.NumberStyles.Float, System.Globalization.CultureInfo.InvariantCulture, out parsedXCost)) {
+				XCost = parsedXCost;
+			}
+		}
+`,
+		},
+		"boolean": {
+			header:  path.NewResponseHeader("X-Cached", "", property.NewBoolean("X-Cached")),
+			varType: "bool?",
+			interpret: `		var xCachedHeader = req.GetResponseHeader("X-Cached");
+		if (xCachedHeader != null) {
+			bool parsedXCached;
+			if (bool.TryParse(xCachedHeader, out parsedXCached)) {
+				XCached = parsedXCached;
+			}
+		}
+`,
+		},
+		"date": {
+			header:  path.NewResponseHeader("X-Reset-At", "", property.NewString("X-Reset-At", "date-time")),
+			varType: "System.DateTime?",
+			interpret: `		var xResetAtHeader = req.GetResponseHeader("X-Reset-At");
+		if (xResetAtHeader != null) {
+			System.DateTime parsedXResetAt;
+			if (System.DateTime.TryParse(xResetAtHeader, System.Globalization.CultureInfo.InvariantCulture, System.Globalization.DateTimeStyles.RoundtripKind, out parsedXResetAt)) {
+				XResetAt = parsedXResetAt;
+			}
+		}
+`,
+		},
+		"no schema": {
+			header:  path.NewResponseHeader("Location", "", nil),
+			varType: "string",
+			interpret: `		var locationHeader = req.GetResponseHeader("Location");
+		if (locationHeader != null) {
+			Location = locationHeader;
+		}
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.varType, tc.header.VariableType())
+			assert.Equal(t, tc.interpret, tc.header.Interpret("req"))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package path
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RetryPolicyClass is the C# that decides whether or not a failed request is
+// sent again
+const RetryPolicyClass = `// RetryPolicy decides whether a failed request is worth sending again, and how
+// long to wait before doing so
+[System.Serializable]
+public class RetryPolicy {
+
+	// How many times a request is sent before giving up, 1 never retries
+	public int MaxAttempts = 3;
+
+	// Seconds waited before the first retry, doubling every retry after
+	public float BaseDelay = 0.5f;
+
+	// The longest a single wait can be, Retry-After included
+	public float MaxDelay = 30f;
+
+	// How much of each wait is randomized, so players who lost their
+	// connection together don't all retry together
+	[Range(0f, 1f)]
+	public float Jitter = 0.5f;
+
+	// Status codes worth retrying
+	public long[] StatusCodes = new long[] { 408, 429, 500, 502, 503, 504 };
+
+	// Whether or not requests that never reached the server are retried
+	public bool RetryNetworkErrors = true;
+
+	// Whether or not requests that timed out are retried
+	public bool RetryTimeouts = true;
+
+	public bool ShouldRetry(IApiResponse response, int attempts) {
+		if (response.Cancelled || attempts >= MaxAttempts) {
+			return false;
+		}
+		if (response.NetworkError != null) {
+			return response.TimedOut ? RetryTimeouts : RetryNetworkErrors;
+		}
+		return System.Array.IndexOf(StatusCodes, response.StatusCode) >= 0;
+	}
+
+	// Delay is how many seconds to wait before the next attempt, going with
+	// what the server asked for through Retry-After on 429s and 503s
+	public float Delay(int attempts, UnityWebRequest req) {
+		if (req.responseCode == 429 || req.responseCode == 503) {
+			var retryAfter = RetryAfter(req.GetResponseHeader("Retry-After"));
+			if (retryAfter.HasValue) {
+				return System.Math.Min(retryAfter.Value, MaxDelay);
+			}
+		}
+		var delay = System.Math.Min(BaseDelay * (float)System.Math.Pow(2, attempts - 1), MaxDelay);
+		return delay * (1f - Jitter * Random.value);
+	}
+
+	// RetryAfter reads a Retry-After header, which is either a number of
+	// seconds or an HTTP date
+	private static float? RetryAfter(string header) {
+		if (string.IsNullOrEmpty(header)) {
+			return null;
+		}
+
+		float seconds;
+		if (float.TryParse(header, System.Globalization.NumberStyles.Float, System.Globalization.CultureInfo.InvariantCulture, out seconds)) {
+			return System.Math.Max(0f, seconds);
+		}
+
+		System.DateTime date;
+		if (System.DateTime.TryParse(header, System.Globalization.CultureInfo.InvariantCulture, System.Globalization.DateTimeStyles.AdjustToUniversal | System.Globalization.DateTimeStyles.AssumeUniversal, out date)) {
+			return (float)System.Math.Max(0, (date - System.DateTime.UtcNow).TotalSeconds);
+		}
+		return null;
+	}
+
+	// Wait yields until the seconds have passed, both as a coroutine and when
+	// the request is awaited
+	public static IEnumerator Wait(float seconds) {
+		var until = Time.realtimeSinceStartup + seconds;
+		while (Time.realtimeSinceStartup < until) {
+			yield return null;
+		}
+	}
+}`
+
+// idempotentMethods are the HTTP methods that are safe to send more than once
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// Retries is whether or not the request is retried according to the config's
+// retry policy. Only idempotent requests are unless told otherwise.
+func (p Path) Retries() bool {
+	if p.retry != nil {
+		return *p.retry
+	}
+	return idempotentMethods[strings.ToUpper(p.httpMethod)]
+}
+
+// retryFields are the members a retryable request uses to keep track of its
+// attempts
+func (p Path) retryFields() string {
+	builder := strings.Builder{}
+	builder.WriteString("\t// How many times the request has been sent\n")
+	builder.WriteString("\tpublic int Attempts { get; private set; }\n\n")
+	builder.WriteString("\t// What decides whether or not the request is sent again\n")
+	builder.WriteString("\tpublic RetryPolicy RetryPolicy { get; private set; }\n\n")
+	fmt.Fprintf(&builder, "\tprivate System.Func<%s> rebuild;\n\n", p.unityWebReqPathName())
+	builder.WriteString("\t// RetryWith has the request rebuilt and sent again whenever the policy says\n")
+	builder.WriteString("\t// it's worth retrying\n")
+	fmt.Fprintf(&builder, "\tpublic %s RetryWith(RetryPolicy retryPolicy, System.Func<%s> rebuild) {\n", p.unityWebReqPathName(), p.unityWebReqPathName())
+	builder.WriteString("\t\tthis.RetryPolicy = retryPolicy;\n\t\tthis.rebuild = rebuild;\n\t\treturn this;\n\t}\n\n")
+	return builder.String()
+}
+
+// retryReset clears out everything interpreted from an earlier attempt, since
+// the same request is reused every time it's sent
+func (p Path) retryReset() string {
+	builder := strings.Builder{}
+	for _, code := range p.orderedResponseCodes() {
+		if p.responses[code] != nil {
+			fmt.Fprintf(&builder, "\t\t%s = default(%s);\n", p.respVariableName(code), p.responses[code].VariableType())
+		}
+	}
+	for _, header := range p.uniqueResponseHeaders() {
+		fmt.Fprintf(&builder, "\t\t%s = default(%s);\n", header.propertyName(), header.VariableType())
+	}
+	return builder.String()
+}
+
+// retryRun is the body of Run for a retryable request, sending it until it
+// succeeds or the policy gives up
+func (p Path) retryRun(indent string) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "%swhile (true) {\n", indent)
+	fmt.Fprintf(&builder, "%s\tAttempts++;\n", indent)
+	builder.WriteString(p.sendAttempt(indent + "\t"))
+	fmt.Fprintf(&builder, "%s\tif (RetryPolicy == null || rebuild == null || RetryPolicy.ShouldRetry(Response, Attempts) == false) {\n", indent)
+	fmt.Fprintf(&builder, "%s\t\tyield break;\n%s\t}\n", indent, indent)
+	fmt.Fprintf(&builder, "%s\tyield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));\n", indent)
+	builder.WriteString(p.cancelCheck(indent + "\t"))
+	fmt.Fprintf(&builder, "\n%s\t// Unity requests can only be sent once\n", indent)
+	fmt.Fprintf(&builder, "%s\t%s retry;\n", indent, p.unityWebReqPathName())
+	fmt.Fprintf(&builder, "%s\ttry {\n%s\t\tretry = rebuild();\n", indent, indent)
+	fmt.Fprintf(&builder, "%s\t} catch (System.Exception e) {\n", indent)
+	fmt.Fprintf(&builder, "%s\t\t// Rebuilding can throw just like the service function can, which has\n", indent)
+	fmt.Fprintf(&builder, "%s\t\t// to end the run instead of escaping it\n", indent)
+	fmt.Fprintf(&builder, "%s\t\tResponse = new %s(e);\n", indent, p.apiResponseType())
+	fmt.Fprintf(&builder, "%s\t\tyield break;\n%s\t}\n", indent, indent)
+	fmt.Fprintf(&builder, "%s\tthis.UnderlyingRequest.Dispose();\n", indent)
+	fmt.Fprintf(&builder, "%s\tthis.UnderlyingRequest = retry.UnderlyingRequest;\n", indent)
+	if p.secured() {
+		fmt.Fprintf(&builder, "%s\tthis.authorizers = retry.authorizers;\n", indent)
+	}
+	fmt.Fprintf(&builder, "%s}\n", indent)
+	return builder.String()
+}
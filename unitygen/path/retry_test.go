@@ -0,0 +1,136 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetriesDefaultToIdempotentMethods(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		expected bool
+	}{
+		"get":    {method: http.MethodGet, expected: true},
+		"head":   {method: http.MethodHead, expected: true},
+		"put":    {method: http.MethodPut, expected: true},
+		"delete": {method: http.MethodDelete, expected: true},
+		"post":   {method: http.MethodPost, expected: false},
+		"patch":  {method: http.MethodPatch, expected: false},
+		"lower":  {method: "get", expected: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			route := path.NewPath("/api/v1/users", "GetUser", tc.method, nil, nil, nil, nil, path.PathOptions{})
+			assert.Equal(t, tc.expected, route.Retries())
+		})
+	}
+}
+
+func Test_RetryOptionOverridesMethod(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	retry, dontRetry := true, false
+
+	// ********************************** ACT *********************************
+	post := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{Retry: &retry})
+	get := path.NewPath("/api/v1/users", "GetUser", http.MethodGet, nil, nil, nil, nil, path.PathOptions{Retry: &dontRetry})
+
+	// ********************************* ASSERT *******************************
+	assert.True(t, post.Retries())
+	assert.False(t, get.Retries())
+}
+
+func Test_RequestsThatDontRetrySendOnce(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	request := route.UnityWebRequest(false)
+	function := route.ServiceFunction(nil)
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, request, "RetryWith")
+	assert.NotContains(t, request, "while (true)")
+	assert.Contains(t, request, "\tpublic UnityWebRequest UnderlyingRequest{ get; }\n")
+	assert.Contains(t, function, "\treturn new CreateUserUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors);\n")
+}
+
+func Test_RetryableRequestsRebuildThroughTheService(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	retry := true
+	route := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{Retry: &retry})
+
+	// ********************************** ACT *********************************
+	request := route.UnityWebRequest(false)
+	function := route.ServiceFunction(nil)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, request, "\tpublic UnityWebRequest UnderlyingRequest{ get; private set; }\n")
+	assert.Contains(t, request, "\tpublic CreateUserUnityWebRequest RetryWith(RetryPolicy retryPolicy, System.Func<CreateUserUnityWebRequest> rebuild) {\n")
+	assert.Contains(t, request, "\t\t\tyield return RetryPolicy.Wait(RetryPolicy.Delay(Attempts, this.UnderlyingRequest));\n")
+	assert.Contains(t, function, "\treturn new CreateUserUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => CreateUser());\n")
+}
+
+func Test_RetryableRequestsForgetEarlierAttempts(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/users",
+		"GetUser",
+		http.MethodGet,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200": path.NewStringResponse("", true),
+			"404": path.NewStringResponse("", true),
+		},
+		nil,
+		path.PathOptions{
+			ResponseHeaders: map[string][]path.ResponseHeader{
+				"200": {path.NewResponseHeader("X-Next-Cursor", "", property.NewString("X-Next-Cursor", ""))},
+			},
+		},
+	)
+
+	// ********************************** ACT *********************************
+	request := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, request, `	public void Interpret(UnityWebRequest req) {
+		success = default(string);
+		notFound = default(string);
+		XNextCursor = default(string);
+		Response = new ApiResponse<string, string>(req, Time.realtimeSinceStartup - sentAt);
+		if (Response.NetworkError != null) {
+			return;
+		}
+		try {
+			if (req.responseCode == 200) {`)
+}
+
+func Test_RetriesThatCantBeRebuiltFailTheResponse(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	retry := true
+	route := path.NewPath("/api/v1/users", "CreateUser", http.MethodPost, nil, nil, nil, nil, path.PathOptions{Retry: &retry})
+
+	// ********************************** ACT *********************************
+	request := route.UnityWebRequest(false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, request, `				// Unity requests can only be sent once
+				CreateUserUnityWebRequest retry;
+				try {
+					retry = rebuild();
+				} catch (System.Exception e) {
+					// Rebuilding can throw just like the service function can, which has
+					// to end the run instead of escaping it
+					Response = new ApiResponse<object, object>(e);
+					yield break;
+				}
+				this.UnderlyingRequest.Dispose();
+`)
+	assert.Contains(t, path.ApiResponseClasses, "\tpublic ApiResponse(System.Exception exception) {\n\t\tthis.NetworkError = exception.Message;\n\t}\n")
+}
@@ -1,5 +1,10 @@
 package path
 
+import (
+	"fmt"
+	"strings"
+)
+
 // SecurityMethodReference is a way to ensure the client properly communicates with
 // a specific route
 type SecurityMethodReference struct {
@@ -12,3 +17,19 @@ func NewSecurityMethodReference(name string) SecurityMethodReference {
 		Identifier: name,
 	}
 }
+
+// SecurityRequirement is a set of security methods that all have to be
+// satisfied together for a request to be authorized. An empty requirement
+// means the request can be made anonymously.
+type SecurityRequirement []SecurityMethodReference
+
+func (r SecurityRequirement) String() string {
+	identifiers := make([]string, len(r))
+	for i, ref := range r {
+		identifiers[i] = ref.Identifier
+	}
+	if len(identifiers) > 1 {
+		return fmt.Sprintf("(%s)", strings.Join(identifiers, " and "))
+	}
+	return strings.Join(identifiers, "")
+}
@@ -0,0 +1,36 @@
+package path
+
+import "fmt"
+
+// StringResponse is a type of response that expects a string.
+type StringResponse struct {
+	description string
+
+	// Whether or not the server sends the string as is (text/plain) instead of
+	// a JSON encoded string
+	plainText bool
+}
+
+// NewStringResponse creates a new string response
+func NewStringResponse(description string, plainText bool) StringResponse {
+	return StringResponse{
+		description: description,
+		plainText:   plainText,
+	}
+}
+
+// Description of the response
+func (resp StringResponse) Description() string {
+	return resp.description
+}
+
+func (resp StringResponse) Interpret(variableName string, downloadHandlerVariableName string) string {
+	if resp.plainText {
+		return fmt.Sprintf("%s = %s.text;", variableName, downloadHandlerVariableName)
+	}
+	return fmt.Sprintf("%s = JsonConvert.DeserializeObject<string>(%s.text);", variableName, downloadHandlerVariableName)
+}
+
+func (resp StringResponse) VariableType() string {
+	return "string"
+}
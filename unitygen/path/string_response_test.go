@@ -0,0 +1,35 @@
+package path_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StringResponse(t *testing.T) {
+	// ARRANGE ================================================================
+	desciption := "A bunch of cool cats"
+	defResp := path.NewStringResponse(desciption, false)
+
+	// ACT ====================================================================
+	desc := defResp.Description()
+	interpret := defResp.Interpret("somethin", "download")
+
+	// ASSERT =================================================================
+	assert.Equal(t, desciption, desc)
+	assert.Equal(t, "somethin = JsonConvert.DeserializeObject<string>(download.text);", interpret)
+	assert.Equal(t, "string", defResp.VariableType())
+}
+
+func Test_StringResponse_PlainText(t *testing.T) {
+	// ARRANGE ================================================================
+	defResp := path.NewStringResponse("", true)
+
+	// ACT ====================================================================
+	interpret := defResp.Interpret("somethin", "download")
+
+	// ASSERT =================================================================
+	assert.Equal(t, "somethin = download.text;", interpret)
+	assert.Equal(t, "string", defResp.VariableType())
+}
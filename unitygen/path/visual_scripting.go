@@ -0,0 +1,191 @@
+package path
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// UnitName is what the Visual Scripting unit that sends the request is called
+func (p Path) UnitName() string {
+	return fmt.Sprintf("%sUnit", convention.ClassName(p.operationID))
+}
+
+// inlineDefaults are the values a unit's inputs can be given without
+// connecting anything to them
+var inlineDefaults = map[string]string{
+	"bool":   "false",
+	"int":    "0",
+	"long":   "0L",
+	"float":  "0f",
+	"double": "0d",
+	"string": `""`,
+}
+
+// statusBranchName is the control output taken for the response code
+func (p Path) statusBranchName(code string) string {
+	return "on" + convention.TitleCase(code)
+}
+
+func unitPort(builder *strings.Builder, portType, name string) {
+	fmt.Fprintf(builder, "\t[Unity.VisualScripting.DoNotSerialize]\n\tpublic Unity.VisualScripting.%s %s;\n\n", portType, name)
+}
+
+// VisualScriptingUnit is a Visual Scripting unit that sends the request,
+// taking its parameters as value inputs. It continues through the status
+// code's branch when one is connected, and through succeeded or failed when
+// not. Service is the class name of the service the path belongs to,
+// configName the scriptable object config requests are sent with, and
+// category where the unit is found in the fuzzy finder.
+func (p Path) VisualScriptingUnit(service, configName, category string) string {
+	name := convention.ClassName(p.operationID)
+	configClassName := convention.TitleCase(configName)
+	codes := p.orderedResponseCodes()
+
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "// %s sends %s from a Visual Scripting graph, and has to be\n", p.UnitName(), name)
+	builder.WriteString("// entered from a coroutine\n")
+	fmt.Fprintf(&builder, "[Unity.VisualScripting.UnitTitle(%q)]\n", name)
+	fmt.Fprintf(&builder, "[Unity.VisualScripting.UnitCategory(%q)]\n", category)
+	fmt.Fprintf(&builder, "public class %s : Unity.VisualScripting.Unit {\n\n", p.UnitName())
+
+	unitPort(&builder, "ControlInput", "enter")
+	unitPort(&builder, "ControlOutput", "succeeded")
+	unitPort(&builder, "ControlOutput", "failed")
+	for _, code := range codes {
+		unitPort(&builder, "ControlOutput", p.statusBranchName(code))
+	}
+	unitPort(&builder, "ValueInput", "config")
+	for _, param := range p.parameters {
+		unitPort(&builder, "ValueInput", convention.CamelCase(param.name)+"Param")
+	}
+	unitPort(&builder, "ValueOutput", "statusCode")
+	unitPort(&builder, "ValueOutput", "error")
+	for _, code := range codes {
+		if p.responses[code] != nil {
+			unitPort(&builder, "ValueOutput", p.respVariableName(code)+"Body")
+		}
+	}
+
+	// Ports
+	builder.WriteString("\tprotected override void Definition() {\n")
+	builder.WriteString("\t\tenter = ControlInputCoroutine(\"enter\", Send);\n")
+	builder.WriteString("\t\tsucceeded = ControlOutput(\"succeeded\");\n")
+	builder.WriteString("\t\tfailed = ControlOutput(\"failed\");\n")
+	for _, code := range codes {
+		fmt.Fprintf(&builder, "\t\t%s = ControlOutput(%q);\n", p.statusBranchName(code), code)
+	}
+	builder.WriteString("\n")
+
+	fmt.Fprintf(&builder, "\t\tconfig = ValueInput<%s>(\"config\", null);\n", configClassName)
+	for _, param := range p.parameters {
+		variableType := param.parameterType.ToVariableType()
+		defaultValue, inline := inlineDefaults[variableType]
+		if param.required == false && param.location != PathParameterLocation {
+			// Optional parameters are only sent when something's connected
+			inline = false
+		}
+		if inline {
+			fmt.Fprintf(&builder, "\t\t%sParam = ValueInput<%s>(%q, %s);\n", convention.CamelCase(param.name), variableType, param.name, defaultValue)
+		} else {
+			fmt.Fprintf(&builder, "\t\t%sParam = ValueInput<%s>(%q);\n", convention.CamelCase(param.name), variableType, param.name)
+		}
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString("\t\tstatusCode = ValueOutput<long>(\"statusCode\");\n")
+	builder.WriteString("\t\terror = ValueOutput<string>(\"error\");\n")
+	for _, code := range codes {
+		if p.responses[code] != nil {
+			fmt.Fprintf(&builder, "\t\t%sBody = ValueOutput<%s>(%q);\n", p.respVariableName(code), p.responses[code].VariableType(), p.respVariableName(code))
+		}
+	}
+	builder.WriteString("\n")
+
+	// Relations, for showing how the ports connect in the graph
+	builder.WriteString("\t\tRequirement(config, enter);\n")
+	for _, param := range p.parameters {
+		fmt.Fprintf(&builder, "\t\tRequirement(%sParam, enter);\n", convention.CamelCase(param.name))
+	}
+	builder.WriteString("\t\tSuccession(enter, succeeded);\n")
+	builder.WriteString("\t\tSuccession(enter, failed);\n")
+	for _, code := range codes {
+		fmt.Fprintf(&builder, "\t\tSuccession(enter, %s);\n", p.statusBranchName(code))
+	}
+	builder.WriteString("\t\tAssignment(enter, statusCode);\n")
+	builder.WriteString("\t\tAssignment(enter, error);\n")
+	for _, code := range codes {
+		if p.responses[code] != nil {
+			fmt.Fprintf(&builder, "\t\tAssignment(enter, %sBody);\n", p.respVariableName(code))
+		}
+	}
+	builder.WriteString("\t}\n\n")
+
+	// Sending
+	builder.WriteString("\tprivate IEnumerator Send(Unity.VisualScripting.Flow flow) {\n")
+	fmt.Fprintf(&builder, "\t\tvar serviceConfig = flow.GetValue<%s>(config);\n", configClassName)
+	builder.WriteString("\t\tif (serviceConfig == null) {\n")
+	builder.WriteString("\t\t\tflow.SetValue(statusCode, 0L);\n")
+	fmt.Fprintf(&builder, "\t\t\tflow.SetValue(error, \"no config to send %s with\");\n", name)
+	builder.WriteString("\t\t\tyield return failed;\n")
+	builder.WriteString("\t\t\tyield break;\n")
+	builder.WriteString("\t\t}\n\n")
+
+	request := fmt.Sprintf("new %s(serviceConfig).%s()", service, name)
+	if len(p.parameters) > 0 {
+		fmt.Fprintf(&builder, "\t\tvar requestParams = new %s();\n", p.qualify(service, p.requestParamClassName()))
+		for _, param := range p.parameters {
+			propertyName := convention.TitleCase(param.name)
+			port := convention.CamelCase(param.name) + "Param"
+			variableType := param.parameterType.ToVariableType()
+			if param.required == false && param.location != PathParameterLocation {
+				fmt.Fprintf(&builder, "\t\tif (%s.hasValidConnection) {\n\t\t\trequestParams.%s = flow.GetValue<%s>(%s);\n\t\t}\n", port, propertyName, variableType, port)
+			} else {
+				fmt.Fprintf(&builder, "\t\trequestParams.%s = flow.GetValue<%s>(%s);\n", propertyName, variableType, port)
+			}
+		}
+		builder.WriteString("\n")
+		request = fmt.Sprintf("new %s(serviceConfig).%s(requestParams)", service, name)
+	}
+
+	fmt.Fprintf(&builder, "\t\tvar req = %s;\n\n", request)
+	builder.WriteString("\t\t// What went wrong is read off the request once it's done running\n")
+	builder.WriteString("\t\treq.DisposeOnComplete = false;\n")
+	builder.WriteString("\t\ttry {\n")
+	builder.WriteString("\t\t\tyield return req.Run();\n")
+	builder.WriteString("\t\t\tflow.SetValue(statusCode, req.Response.StatusCode);\n")
+	builder.WriteString("\t\t\tflow.SetValue(error, req.Response.IsSuccess ? null : new ApiError(req).ToString());\n")
+	for _, code := range codes {
+		if p.responses[code] != nil {
+			fmt.Fprintf(&builder, "\t\t\tflow.SetValue(%sBody, req.%s);\n", p.respVariableName(code), p.respVariableName(code))
+		}
+	}
+	builder.WriteString("\t\t} finally {\n")
+	builder.WriteString("\t\t\treq.Dispose();\n")
+	builder.WriteString("\t\t}\n\n")
+
+	builder.WriteString("\t\tvar next = req.Response.IsSuccess ? succeeded : failed;\n")
+	if len(codes) > 0 {
+		builder.WriteString("\t\tvar code = req.Response.StatusCode;\n")
+		builder.WriteString("\t\tif (req.Response.NetworkError == null && req.Response.Cancelled == false) {\n")
+		builder.WriteString("\t\t\t")
+		for codeIndex, code := range codes {
+			branch := p.statusBranchName(code)
+			if code == "default" {
+				builder.WriteString("{\n")
+			} else {
+				fmt.Fprintf(&builder, "if (%s) {\n", p.statusCondition(code, "code"))
+			}
+			fmt.Fprintf(&builder, "\t\t\t\tnext = %s.hasValidConnection ? %s : next;\n\t\t\t}", branch, branch)
+			if codeIndex < len(codes)-1 {
+				builder.WriteString(" else ")
+			}
+		}
+		builder.WriteString("\n\t\t}\n")
+	}
+	builder.WriteString("\t\tyield return next;\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,148 @@
+package path_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model/property"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VisualScriptingUnitWithoutParameters(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{})
+
+	// ********************************** ACT *********************************
+	code := route.VisualScriptingUnit("HealthService", "ServiceConfig", "Api/HealthService")
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "PingUnit", route.UnitName())
+	assert.Equal(t, `// PingUnit sends Ping from a Visual Scripting graph, and has to be
+// entered from a coroutine
+[Unity.VisualScripting.UnitTitle("Ping")]
+[Unity.VisualScripting.UnitCategory("Api/HealthService")]
+public class PingUnit : Unity.VisualScripting.Unit {
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ControlInput enter;
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ControlOutput succeeded;
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ControlOutput failed;
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ValueInput config;
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ValueOutput statusCode;
+
+	[Unity.VisualScripting.DoNotSerialize]
+	public Unity.VisualScripting.ValueOutput error;
+
+	protected override void Definition() {
+		enter = ControlInputCoroutine("enter", Send);
+		succeeded = ControlOutput("succeeded");
+		failed = ControlOutput("failed");
+
+		config = ValueInput<ServiceConfig>("config", null);
+
+		statusCode = ValueOutput<long>("statusCode");
+		error = ValueOutput<string>("error");
+
+		Requirement(config, enter);
+		Succession(enter, succeeded);
+		Succession(enter, failed);
+		Assignment(enter, statusCode);
+		Assignment(enter, error);
+	}
+
+	private IEnumerator Send(Unity.VisualScripting.Flow flow) {
+		var serviceConfig = flow.GetValue<ServiceConfig>(config);
+		if (serviceConfig == null) {
+			flow.SetValue(statusCode, 0L);
+			flow.SetValue(error, "no config to send Ping with");
+			yield return failed;
+			yield break;
+		}
+
+		var req = new HealthService(serviceConfig).Ping();
+
+		// What went wrong is read off the request once it's done running
+		req.DisposeOnComplete = false;
+		try {
+			yield return req.Run();
+			flow.SetValue(statusCode, req.Response.StatusCode);
+			flow.SetValue(error, req.Response.IsSuccess ? null : new ApiError(req).ToString());
+		} finally {
+			req.Dispose();
+		}
+
+		var next = req.Response.IsSuccess ? succeeded : failed;
+		yield return next;
+	}
+}`, code)
+}
+
+func Test_VisualScriptingUnitTakesParametersAndBranchesOnStatus(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := path.NewPath(
+		"/api/v1/leaderboards/{leaderboardId}",
+		"GetLeaderboard",
+		http.MethodGet,
+		nil,
+		nil,
+		map[string]path.Response{
+			"200":     path.NewDefinitionResponse("A successful response.", model.NewDefinitionReference("#/definitions/Leaderboard")),
+			"404":     nil,
+			"default": path.NewDefinitionResponse("An unexpected error response", model.NewDefinitionReference("#/definitions/runtimeError")),
+		},
+		[]path.Parameter{
+			path.NewParameter(path.PathParameterLocation, "leaderboardId", true, property.NewString("leaderboardId", "")),
+			path.NewParameter(path.QueryParameterLocation, "limit", false, property.NewInteger("limit", "")),
+		},
+		path.PathOptions{},
+	)
+
+	// ********************************** ACT *********************************
+	code := route.VisualScriptingUnit("LeaderboardService", "RecoludeConfig", "Recolude/LeaderboardService")
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `		on200 = ControlOutput("200");
+		on404 = ControlOutput("404");
+		onDefault = ControlOutput("default");
+
+		config = ValueInput<RecoludeConfig>("config", null);
+		leaderboardIdParam = ValueInput<string>("leaderboardId", "");
+		limitParam = ValueInput<int>("limit");
+
+		statusCode = ValueOutput<long>("statusCode");
+		error = ValueOutput<string>("error");
+		successBody = ValueOutput<Leaderboard>("success");
+		fallbackResponseBody = ValueOutput<RuntimeError>("fallbackResponse");
+`)
+	assert.Contains(t, code, `		var requestParams = new LeaderboardService.GetLeaderboardRequestParams();
+		requestParams.LeaderboardId = flow.GetValue<string>(leaderboardIdParam);
+		if (limitParam.hasValidConnection) {
+			requestParams.Limit = flow.GetValue<int>(limitParam);
+		}
+
+		var req = new LeaderboardService(serviceConfig).GetLeaderboard(requestParams);
+`)
+	assert.Contains(t, code, `		var next = req.Response.IsSuccess ? succeeded : failed;
+		var code = req.Response.StatusCode;
+		if (req.Response.NetworkError == null && req.Response.Cancelled == false) {
+			if (code == 200) {
+				next = on200.hasValidConnection ? on200 : next;
+			} else if (code == 404) {
+				next = on404.hasValidConnection ? on404 : next;
+			} else {
+				next = onDefault.hasValidConnection ? onDefault : next;
+			}
+		}
+		yield return next;
+`)
+}
@@ -0,0 +1,202 @@
+package unitygen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+)
+
+// SecretStorage is where the generated scriptable object config keeps secret
+// values like passwords and API keys
+type SecretStorage string
+
+const (
+	// AssetSecretStorage serializes secrets into the config's .asset file
+	AssetSecretStorage SecretStorage = "asset"
+
+	// PrefsSecretStorage keeps secrets in EditorPrefs while in the editor and
+	// PlayerPrefs in builds
+	PrefsSecretStorage SecretStorage = "prefs"
+
+	// EnvironmentSecretStorage reads secrets from command line arguments,
+	// falling back to environment variables
+	EnvironmentSecretStorage SecretStorage = "environment"
+
+	// StreamingAssetsSecretStorage loads secrets from a git-ignored JSON file
+	// in StreamingAssets
+	StreamingAssetsSecretStorage SecretStorage = "streaming-assets"
+)
+
+// SecretStorages is every supported secret storage
+var SecretStorages = []SecretStorage{
+	AssetSecretStorage,
+	PrefsSecretStorage,
+	EnvironmentSecretStorage,
+	StreamingAssetsSecretStorage,
+}
+
+// ParseSecretStorage interprets the name of a secret storage
+func ParseSecretStorage(name string) (SecretStorage, error) {
+	for _, storage := range SecretStorages {
+		if string(storage) == strings.ToLower(name) {
+			return storage, nil
+		}
+	}
+	return "", fmt.Errorf("unknown secret storage %q", name)
+}
+
+// serialized is whether or not secrets end up in the .asset file
+func (s SecretStorage) serialized() bool {
+	return s == "" || s == AssetSecretStorage
+}
+
+// className is the generated static class that reads and writes secrets
+func (s SecretStorage) className(configName string) string {
+	return convention.TitleCase(configName) + "Secrets"
+}
+
+// key is what a variable is stored under
+func (s SecretStorage) key(configName string, variable security.Variable) string {
+	switch s {
+	case PrefsSecretStorage:
+		return convention.TitleCase(configName) + "." + variable.Name()
+	case EnvironmentSecretStorage:
+		return convention.ScreamingSnakeCase(variable.Name())
+	}
+	return variable.Name()
+}
+
+// read is C# that reads a variable out of storage
+func (s SecretStorage) read(configName string, variable security.Variable) string {
+	return fmt.Sprintf("%s.Read(%q)", s.className(configName), s.key(configName, variable))
+}
+
+// write is C# that writes a value to storage
+func (s SecretStorage) write(configName string, variable security.Variable, value string) string {
+	return fmt.Sprintf("%s.Write(%q, %s)", s.className(configName), s.key(configName, variable), value)
+}
+
+// source is C# describing where a variable is stored, for the inspector
+func (s SecretStorage) source(configName string, variable security.Variable) string {
+	return fmt.Sprintf("%s.Source(%q)", s.className(configName), s.key(configName, variable))
+}
+
+// ToCSharp generates the static class that reads and writes secrets
+func (s SecretStorage) ToCSharp(configName string) string {
+	properConfigName := convention.TitleCase(configName)
+	className := s.className(configName)
+
+	switch s {
+	case PrefsSecretStorage:
+		return fmt.Sprintf(`// %s keeps %s's secrets in EditorPrefs while in the
+// editor and PlayerPrefs in builds, so they never end up in the asset
+public static class %s {
+
+	public static string Read(string key) {
+#if UNITY_EDITOR
+		return UnityEditor.EditorPrefs.GetString(key, "");
+#else
+		return PlayerPrefs.GetString(key, "");
+#endif
+	}
+
+	public static void Write(string key, string value) {
+#if UNITY_EDITOR
+		UnityEditor.EditorPrefs.SetString(key, value);
+#else
+		PlayerPrefs.SetString(key, value);
+#endif
+	}
+
+	// Source describes where a secret is kept
+	public static string Source(string key) {
+		return "EditorPrefs \"" + key + "\" (PlayerPrefs in builds)";
+	}
+}`, className, properConfigName, className)
+
+	case EnvironmentSecretStorage:
+		return fmt.Sprintf(`// %s reads %s's secrets from command line arguments
+// (--KEY=value or --KEY value), falling back to environment variables
+public static class %s {
+
+	public static string Read(string key) {
+		var args = System.Environment.GetCommandLineArgs();
+		for (int i = 0; i < args.Length; i++) {
+			if (args[i].StartsWith("--" + key + "=")) {
+				return args[i].Substring(key.Length + 3);
+			}
+			if (args[i] == "--" + key && i + 1 < args.Length) {
+				return args[i + 1];
+			}
+		}
+		return System.Environment.GetEnvironmentVariable(key);
+	}
+
+	// Write only lasts as long as the process does, and doesn't override
+	// values passed on the command line
+	public static void Write(string key, string value) {
+		System.Environment.SetEnvironmentVariable(key, value);
+	}
+
+	// Source describes where a secret is kept
+	public static string Source(string key) {
+		return "--" + key + " argument or " + key + " environment variable";
+	}
+}`, className, properConfigName, className)
+
+	case StreamingAssetsSecretStorage:
+		return fmt.Sprintf(`// %s loads %s's secrets from a JSON file in
+// StreamingAssets that's kept out of version control. Android and WebGL builds
+// can't read StreamingAssets straight from disk, so use a different storage there.
+public static class %s {
+
+	public const string FileName = "%s.secrets.json";
+
+	private static System.Collections.Generic.Dictionary<string, string> secrets;
+
+	private static string FilePath { get { return System.IO.Path.Combine(Application.streamingAssetsPath, FileName); } }
+
+	private static System.Collections.Generic.Dictionary<string, string> Secrets {
+		get {
+			if (secrets == null) {
+				if (System.IO.File.Exists(FilePath)) {
+					secrets = JsonConvert.DeserializeObject<System.Collections.Generic.Dictionary<string, string>>(System.IO.File.ReadAllText(FilePath));
+				}
+				if (secrets == null) {
+					secrets = new System.Collections.Generic.Dictionary<string, string>();
+				}
+			}
+			return secrets;
+		}
+	}
+
+	public static string Read(string key) {
+		string value;
+		return Secrets.TryGetValue(key, out value) ? value : "";
+	}
+
+	// Write saves the file back to disk while in the editor, making sure it's
+	// git-ignored
+	public static void Write(string key, string value) {
+		Secrets[key] = value;
+#if UNITY_EDITOR
+		System.IO.Directory.CreateDirectory(Application.streamingAssetsPath);
+		System.IO.File.WriteAllText(FilePath, JsonConvert.SerializeObject(Secrets, Formatting.Indented));
+		var gitignore = System.IO.Path.Combine(Application.streamingAssetsPath, ".gitignore");
+		if (System.IO.File.Exists(gitignore) == false || System.IO.File.ReadAllText(gitignore).Contains(FileName) == false) {
+			System.IO.File.AppendAllText(gitignore, FileName + "\n" + FileName + ".meta\n");
+		}
+#endif
+	}
+
+	// Source describes where a secret is kept
+	public static string Source(string key) {
+		return "\"" + key + "\" in StreamingAssets/" + FileName;
+	}
+}`, className, properConfigName, className, properConfigName)
+	}
+
+	return ""
+}
@@ -0,0 +1,34 @@
+package unitygen_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretStorage(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    unitygen.SecretStorage
+		wantErr string
+	}{
+		"asset":            {input: "asset", want: unitygen.AssetSecretStorage},
+		"prefs":            {input: "Prefs", want: unitygen.PrefsSecretStorage},
+		"environment":      {input: "environment", want: unitygen.EnvironmentSecretStorage},
+		"streaming assets": {input: "streaming-assets", want: unitygen.StreamingAssetsSecretStorage},
+		"unknown":          {input: "vault", wantErr: `unknown secret storage "vault"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			storage, err := unitygen.ParseSecretStorage(tc.input)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, storage)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package security
 
 import (
 	"fmt"
+	"net/url"
 
 	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
 )
@@ -14,7 +15,13 @@ const (
 	Header APIKeyLocation = "header"
 
 	// Body indicates the API Key should be found inside the body of the request
-	Body = "body"
+	Body APIKeyLocation = "body"
+
+	// Query indicates the API Key should be found in the request's query string
+	Query APIKeyLocation = "query"
+
+	// Cookie indicates the API Key should be found in the request's cookies
+	Cookie APIKeyLocation = "cookie"
 )
 
 // APIKeyAuth is a guard on route that requires a specific API key to be
@@ -49,10 +56,45 @@ func (key APIKeyAuth) name() string {
 	return key.key
 }
 
+// Variables is the key itself, which is kept secret
+func (key APIKeyAuth) Variables() []Variable {
+	return []Variable{
+		NewVariable(convention.TitleCase(key.Identifier()), key.String(), true),
+	}
+}
+
+// CredentialsAvailable checks whether or not the key has been set
+func (key APIKeyAuth) CredentialsAvailable() string {
+	return providerAvailable(key.Identifier(), convention.TitleCase(key.Identifier()))
+}
+
 // ModifyNetworkRequest generates C# code that appends this API Key to a
 // specific network request
 func (key APIKeyAuth) ModifyNetworkRequest() string {
-	return fmt.Sprintf("unityNetworkReq.SetRequestHeader(\"%s\", this.Config.%s);", key.name(), convention.TitleCase(key.Identifier()))
+	return applyThroughProvider(key.Identifier(), key.modification())
+}
+
+func (key APIKeyAuth) modification() string {
+	configVariable := providedVariable(key.Identifier(), convention.TitleCase(key.Identifier()))
+	switch key.loc {
+	case Query:
+		// The url might already have a query string from other parameters
+		return fmt.Sprintf(
+			"unityNetworkReq.url += (unityNetworkReq.url.Contains(\"?\") ? \"&\" : \"?\") + \"%s=\" + UnityWebRequest.EscapeURL(%s);",
+			url.QueryEscape(key.name()),
+			configVariable,
+		)
+
+	case Cookie:
+		// Keep whatever cookies have already been set on the request, escaping
+		// the value since characters like ; and , would end the cookie early
+		return fmt.Sprintf(
+			"unityNetworkReq.SetRequestHeader(\"Cookie\", (string.IsNullOrEmpty(unityNetworkReq.GetRequestHeader(\"Cookie\")) ? \"\" : unityNetworkReq.GetRequestHeader(\"Cookie\") + \"; \") + \"%s=\" + UnityWebRequest.EscapeURL(%s));",
+			key.name(),
+			configVariable,
+		)
+	}
+	return fmt.Sprintf("unityNetworkReq.SetRequestHeader(\"%s\", %s);", key.name(), configVariable)
 }
 
 func (key APIKeyAuth) String() string {
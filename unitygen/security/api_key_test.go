@@ -15,9 +15,37 @@ func Test_APIKey(t *testing.T) {
 	id := route.Identifier()
 	modfier := route.ModifyNetworkRequest()
 	str := route.String()
+	variables := route.Variables()
 
 	// ********************************* ASSERT *******************************
 	assert.Equal(t, "someIdentifier", id)
-	assert.Equal(t, `unityNetworkReq.SetRequestHeader("SomeName", this.Config.SomeIdentifier);`, modfier)
+	if assert.Len(t, variables, 1) {
+		assert.Equal(t, "SomeIdentifier", variables[0].Name())
+		assert.True(t, variables[0].Secret())
+	}
+	assert.Equal(t, `CredentialProviders.Apply(authorizers, this.Config.SomeIdentifierCredentials, () => { unityNetworkReq.SetRequestHeader("SomeName", this.Config.SomeIdentifierCredentials.SomeIdentifier); });`, modfier)
 	assert.Equal(t, "SomeIdentifier is a API Key 'SomeName' found in a request's header", str)
 }
+
+func Test_APIKeyInQuery(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := security.NewAPIKey("analyticsKey", "api_key", security.Query)
+
+	// ********************************** ACT *********************************
+	modfier := route.ModifyNetworkRequest()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `CredentialProviders.Apply(authorizers, this.Config.AnalyticsKeyCredentials, () => { unityNetworkReq.url += (unityNetworkReq.url.Contains("?") ? "&" : "?") + "api_key=" + UnityWebRequest.EscapeURL(this.Config.AnalyticsKeyCredentials.AnalyticsKey); });`, modfier)
+	assert.Equal(t, "AnalyticsKey is a API Key 'api_key' found in a request's query", route.String())
+}
+
+func Test_APIKeyInCookie(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	route := security.NewAPIKey("partnerKey", "PARTNER_SESSION", security.Cookie)
+
+	// ********************************** ACT *********************************
+	modfier := route.ModifyNetworkRequest()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `CredentialProviders.Apply(authorizers, this.Config.PartnerKeyCredentials, () => { unityNetworkReq.SetRequestHeader("Cookie", (string.IsNullOrEmpty(unityNetworkReq.GetRequestHeader("Cookie")) ? "" : unityNetworkReq.GetRequestHeader("Cookie") + "; ") + "PARTNER_SESSION=" + UnityWebRequest.EscapeURL(this.Config.PartnerKeyCredentials.PartnerKey)); });`, modfier)
+}
@@ -6,7 +6,32 @@ type Auth interface {
 	// specific type of authentication desired for a specific route
 	Identifier() string
 
+	// Variables are the values the generated config has to hold for the guard
+	// to be able to modify requests
+	Variables() []Variable
+
+	// CredentialsAvailable is a C# boolean expression that determines whether
+	// or not the config has what it needs to satisfy the guard
+	CredentialsAvailable() string
+
 	ModifyNetworkRequest() string
 
 	String() string
 }
+
+// RequestAuthorizer is a guard that keeps state on the generated config and
+// has to do work (like fetching a token) before a request can be sent
+type RequestAuthorizer interface {
+	Auth
+
+	// StateName is the name of the config member implementing the generated
+	// IRequestAuthorizer interface
+	StateName() string
+
+	// ConfigMembers is all runtime state the guard keeps on the config
+	ConfigMembers() []ConfigMember
+
+	// SupportingClasses is the C# the state depends on. Guards that share
+	// classes return the same code for them so they only get written out once.
+	SupportingClasses() []string
+}
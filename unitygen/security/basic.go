@@ -0,0 +1,61 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// BasicAuth is a guard on a route that requires a username and password sent
+// through HTTP basic authentication
+type BasicAuth struct {
+	identifier string
+}
+
+// NewBasicAuth creates a new basic authentication definition
+func NewBasicAuth(identifier string) BasicAuth {
+	return BasicAuth{
+		identifier: identifier,
+	}
+}
+
+// Identifier returns a unique string that represents how the swagger file
+// refers to the definition
+func (b BasicAuth) Identifier() string {
+	return b.identifier
+}
+
+func (b BasicAuth) usernameVariable() string {
+	return convention.TitleCase(b.Identifier()) + "Username"
+}
+
+func (b BasicAuth) passwordVariable() string {
+	return convention.TitleCase(b.Identifier()) + "Password"
+}
+
+// Variables are the username and password
+func (b BasicAuth) Variables() []Variable {
+	return []Variable{
+		NewVariable(b.usernameVariable(), fmt.Sprintf("Username sent with %s", convention.TitleCase(b.Identifier())), false),
+		NewVariable(b.passwordVariable(), fmt.Sprintf("Password sent with %s", convention.TitleCase(b.Identifier())), true),
+	}
+}
+
+// CredentialsAvailable checks whether or not a username has been set
+func (b BasicAuth) CredentialsAvailable() string {
+	return providerAvailable(b.Identifier(), b.usernameVariable())
+}
+
+// ModifyNetworkRequest generates C# code that sets the base64 encoded
+// credentials as the request's Authorization header
+func (b BasicAuth) ModifyNetworkRequest() string {
+	return applyThroughProvider(b.Identifier(), fmt.Sprintf(
+		"unityNetworkReq.SetRequestHeader(\"Authorization\", \"Basic \" + System.Convert.ToBase64String(Encoding.UTF8.GetBytes(%s + \":\" + %s)));",
+		providedVariable(b.Identifier(), b.usernameVariable()),
+		providedVariable(b.Identifier(), b.passwordVariable()),
+	))
+}
+
+func (b BasicAuth) String() string {
+	return fmt.Sprintf("%s is HTTP basic authentication", convention.TitleCase(b.Identifier()))
+}
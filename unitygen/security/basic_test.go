@@ -0,0 +1,31 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BasicAuth(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewBasicAuth("adminAuth")
+
+	// ********************************** ACT *********************************
+	variables := guard.Variables()
+	available := guard.CredentialsAvailable()
+	modifier := guard.ModifyNetworkRequest()
+	str := guard.String()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "adminAuth", guard.Identifier())
+	if assert.Len(t, variables, 2) {
+		assert.Equal(t, "AdminAuthUsername", variables[0].Name())
+		assert.False(t, variables[0].Secret())
+		assert.Equal(t, "AdminAuthPassword", variables[1].Name())
+		assert.True(t, variables[1].Secret())
+	}
+	assert.Equal(t, "CredentialProviders.Available(this.Config.AdminAuthCredentials, this.Config.AdminAuthCredentials.AdminAuthUsername)", available)
+	assert.Equal(t, `CredentialProviders.Apply(authorizers, this.Config.AdminAuthCredentials, () => { unityNetworkReq.SetRequestHeader("Authorization", "Basic " + System.Convert.ToBase64String(Encoding.UTF8.GetBytes(this.Config.AdminAuthCredentials.AdminAuthUsername + ":" + this.Config.AdminAuthCredentials.AdminAuthPassword))); });`, modifier)
+	assert.Equal(t, "AdminAuth is HTTP basic authentication", str)
+}
@@ -0,0 +1,67 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// BearerAuth is a guard on a route that requires a token sent through the
+// Authorization header
+type BearerAuth struct {
+	identifier string
+
+	// Hint as to how the token is formatted (JWT, etc.). Purely documentation
+	bearerFormat string
+}
+
+// NewBearerAuth creates a new bearer token definition
+func NewBearerAuth(identifier, bearerFormat string) BearerAuth {
+	return BearerAuth{
+		identifier:   identifier,
+		bearerFormat: bearerFormat,
+	}
+}
+
+// Identifier returns a unique string that represents how the swagger file
+// refers to the definition
+func (b BearerAuth) Identifier() string {
+	return b.identifier
+}
+
+// BearerFormat is how the token is formatted, if the spec says
+func (b BearerAuth) BearerFormat() string {
+	return b.bearerFormat
+}
+
+func (b BearerAuth) tokenVariable() string {
+	return convention.TitleCase(b.Identifier()) + "Token"
+}
+
+// Variables is the token
+func (b BearerAuth) Variables() []Variable {
+	return []Variable{
+		NewVariable(b.tokenVariable(), b.String(), true),
+	}
+}
+
+// CredentialsAvailable checks whether or not the token has been set
+func (b BearerAuth) CredentialsAvailable() string {
+	return providerAvailable(b.Identifier(), b.tokenVariable())
+}
+
+// ModifyNetworkRequest generates C# code that sets the token as the request's
+// Authorization header
+func (b BearerAuth) ModifyNetworkRequest() string {
+	return applyThroughProvider(b.Identifier(), fmt.Sprintf(
+		"unityNetworkReq.SetRequestHeader(\"Authorization\", \"Bearer \" + %s);",
+		providedVariable(b.Identifier(), b.tokenVariable()),
+	))
+}
+
+func (b BearerAuth) String() string {
+	if b.bearerFormat == "" {
+		return fmt.Sprintf("%s is a bearer token", convention.TitleCase(b.Identifier()))
+	}
+	return fmt.Sprintf("%s is a bearer token formatted as %s", convention.TitleCase(b.Identifier()), b.bearerFormat)
+}
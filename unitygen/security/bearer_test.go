@@ -0,0 +1,29 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BearerAuth(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewBearerAuth("sessionAuth", "JWT")
+
+	// ********************************** ACT *********************************
+	variables := guard.Variables()
+	available := guard.CredentialsAvailable()
+	modifier := guard.ModifyNetworkRequest()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "sessionAuth", guard.Identifier())
+	if assert.Len(t, variables, 1) {
+		assert.Equal(t, "SessionAuthToken", variables[0].Name())
+		assert.Equal(t, "SessionAuth is a bearer token formatted as JWT", variables[0].Description())
+		assert.True(t, variables[0].Secret())
+	}
+	assert.Equal(t, "CredentialProviders.Available(this.Config.SessionAuthCredentials, this.Config.SessionAuthCredentials.SessionAuthToken)", available)
+	assert.Equal(t, `CredentialProviders.Apply(authorizers, this.Config.SessionAuthCredentials, () => { unityNetworkReq.SetRequestHeader("Authorization", "Bearer " + this.Config.SessionAuthCredentials.SessionAuthToken); });`, modifier)
+	assert.Equal(t, "SessionAuth is a bearer token", security.NewBearerAuth("sessionAuth", "").String())
+}
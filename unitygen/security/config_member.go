@@ -0,0 +1,41 @@
+package security
+
+// ConfigMember is runtime state (like a token store) the generated config
+// lazily creates and holds on behalf of a guard
+type ConfigMember struct {
+	name        string
+	memberType  string
+	initializer string
+	description string
+}
+
+// NewConfigMember creates a new member. The initializer is a C# expression
+// evaluated inside the config.
+func NewConfigMember(name, memberType, initializer, description string) ConfigMember {
+	return ConfigMember{
+		name:        name,
+		memberType:  memberType,
+		initializer: initializer,
+		description: description,
+	}
+}
+
+// Name of the property on the config
+func (m ConfigMember) Name() string {
+	return m.name
+}
+
+// Type is the C# type of the member
+func (m ConfigMember) Type() string {
+	return m.memberType
+}
+
+// Initializer is the C# expression that builds the member
+func (m ConfigMember) Initializer() string {
+	return m.initializer
+}
+
+// Description of what the member is for
+func (m ConfigMember) Description() string {
+	return m.description
+}
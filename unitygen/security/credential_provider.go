@@ -0,0 +1,103 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// CredentialProvider is the name of the generated interface that hands a
+// guard its variables at runtime
+func CredentialProvider(identifier string) string {
+	return "I" + convention.TitleCase(identifier) + "CredentialProvider"
+}
+
+// CredentialProviderProperty is the name of the config property holding the
+// provider a guard reads its variables from
+func CredentialProviderProperty(identifier string) string {
+	return convention.TitleCase(identifier) + "Credentials"
+}
+
+// providedVariable is C# that reads a variable through the guard's provider
+func providedVariable(identifier, variable string) string {
+	return fmt.Sprintf("this.Config.%s.%s", CredentialProviderProperty(identifier), variable)
+}
+
+// providerAvailable is C# that checks whether or not the guard's provider has
+// (or can get) the given variable
+func providerAvailable(identifier, variable string) string {
+	return fmt.Sprintf(
+		"CredentialProviders.Available(this.Config.%s, %s)",
+		CredentialProviderProperty(identifier),
+		providedVariable(identifier, variable),
+	)
+}
+
+// applyThroughProvider wraps a statement that modifies the request so it
+// waits on async providers to prepare their credentials first
+func applyThroughProvider(identifier, statement string) string {
+	return fmt.Sprintf(
+		"CredentialProviders.Apply(authorizers, this.Config.%s, () => { %s });",
+		CredentialProviderProperty(identifier),
+		statement,
+	)
+}
+
+// CredentialProviderClasses is the C# shared by every generated credential
+// provider
+const CredentialProviderClasses = `// IAsyncCredentialProvider is implemented by credential providers that have
+// to do work, like logging a player in, before their credentials can be read
+public interface IAsyncCredentialProvider {
+
+	// Whether or not the provider has, or knows how to get, its credentials
+	bool CanProvide { get; }
+
+	// Gets the credentials ready, called before every request that uses them
+	IEnumerator Prepare();
+}
+
+// CredentialProviders applies credentials to requests, waiting on async
+// providers to prepare them first
+public static class CredentialProviders {
+
+	private class DeferredCredentials : IRequestAuthorizer {
+
+		private IAsyncCredentialProvider provider;
+
+		private System.Action apply;
+
+		public DeferredCredentials(IAsyncCredentialProvider provider, System.Action apply) {
+			this.provider = provider;
+			this.apply = apply;
+		}
+
+		public IEnumerator Authorize(UnityWebRequest req) {
+			yield return this.provider.Prepare();
+			this.apply();
+		}
+
+		public void Inspect(UnityWebRequest req) {
+		}
+	}
+
+	// Available determines whether or not the provider has the credential, or
+	// can get it
+	public static bool Available(object provider, string credential) {
+		var asyncProvider = provider as IAsyncCredentialProvider;
+		if (asyncProvider != null) {
+			return asyncProvider.CanProvide;
+		}
+		return string.IsNullOrEmpty(credential) == false;
+	}
+
+	// Apply modifies the request right away, unless the provider is async, in
+	// which case the modification waits until the credentials are ready
+	public static void Apply(System.Collections.Generic.List<IRequestAuthorizer> authorizers, object provider, System.Action apply) {
+		var asyncProvider = provider as IAsyncCredentialProvider;
+		if (asyncProvider != null) {
+			authorizers.Add(new DeferredCredentials(asyncProvider, apply));
+			return;
+		}
+		apply();
+	}
+}`
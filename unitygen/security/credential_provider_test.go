@@ -0,0 +1,18 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CredentialProviderNames(t *testing.T) {
+	// ********************************** ACT *********************************
+	provider := security.CredentialProvider("devKeyAuth")
+	property := security.CredentialProviderProperty("devKeyAuth")
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "IDevKeyAuthCredentialProvider", provider)
+	assert.Equal(t, "DevKeyAuthCredentials", property)
+}
@@ -0,0 +1,696 @@
+package security
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// OAuth2FlowType is how a client goes about obtaining an access token
+type OAuth2FlowType string
+
+const (
+	// ImplicitFlow hands the token straight to the client from the
+	// authorization URL
+	ImplicitFlow OAuth2FlowType = "implicit"
+
+	// PasswordFlow exchanges a username and password for a token
+	PasswordFlow OAuth2FlowType = "password"
+
+	// ClientCredentialsFlow exchanges a client ID and secret for a token
+	// (swagger 2.0 calls this "application")
+	ClientCredentialsFlow OAuth2FlowType = "clientCredentials"
+
+	// AuthorizationCodeFlow has the user log in through the authorization URL
+	// and exchanges the resulting code for a token (swagger 2.0 calls this
+	// "accessCode")
+	AuthorizationCodeFlow OAuth2FlowType = "authorizationCode"
+)
+
+// OAuth2Flow is a single way of obtaining a token described by an OAuth2
+// security definition
+type OAuth2Flow struct {
+	flowType         OAuth2FlowType
+	authorizationURL string
+	tokenURL         string
+	refreshURL       string
+	scopes           map[string]string
+}
+
+// NewOAuth2Flow creates a new flow
+func NewOAuth2Flow(flowType OAuth2FlowType, authorizationURL, tokenURL, refreshURL string, scopes map[string]string) OAuth2Flow {
+	return OAuth2Flow{
+		flowType:         flowType,
+		authorizationURL: authorizationURL,
+		tokenURL:         tokenURL,
+		refreshURL:       refreshURL,
+		scopes:           scopes,
+	}
+}
+
+// Type of flow
+func (f OAuth2Flow) Type() OAuth2FlowType {
+	return f.flowType
+}
+
+// AuthorizationURL is where the user goes to grant access
+func (f OAuth2Flow) AuthorizationURL() string {
+	return f.authorizationURL
+}
+
+// TokenURL is where tokens are requested from
+func (f OAuth2Flow) TokenURL() string {
+	return f.tokenURL
+}
+
+// RefreshURL is where tokens are refreshed. Empty means the token URL is used
+func (f OAuth2Flow) RefreshURL() string {
+	return f.refreshURL
+}
+
+// Scopes available for the flow, mapped to their description
+func (f OAuth2Flow) Scopes() map[string]string {
+	return f.scopes
+}
+
+// ScopeNames are the names of all scopes available for the flow, sorted
+func (f OAuth2Flow) ScopeNames() []string {
+	names := make([]string, 0, len(f.scopes))
+	for scope := range f.scopes {
+		names = append(names, scope)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// grantType is the grant_type sent to the token URL when the token store has
+// to fetch a token on it's own
+func (f OAuth2Flow) grantType() string {
+	switch f.flowType {
+	case ClientCredentialsFlow:
+		return "client_credentials"
+	case PasswordFlow:
+		return "password"
+	}
+	return ""
+}
+
+// OAuth2 is a guard on a route that requires a bearer token obtained through
+// one of the definition's flows
+type OAuth2 struct {
+	identifier string
+	flows      []OAuth2Flow
+}
+
+// NewOAuth2 creates a new OAuth2 security definition
+func NewOAuth2(identifier string, flows []OAuth2Flow) OAuth2 {
+	sorted := make([]OAuth2Flow, len(flows))
+	copy(sorted, flows)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].flowType < sorted[j].flowType })
+	return OAuth2{
+		identifier: identifier,
+		flows:      sorted,
+	}
+}
+
+// Identifier returns a unique string that represents how the swagger file
+// refers to the definition
+func (o OAuth2) Identifier() string {
+	return o.identifier
+}
+
+// Flows are the different ways a token can be obtained, sorted by type
+func (o OAuth2) Flows() []OAuth2Flow {
+	return o.flows
+}
+
+// Flow finds the flow of a specific type, if the definition has one
+func (o OAuth2) Flow(flowType OAuth2FlowType) (OAuth2Flow, bool) {
+	for _, flow := range o.flows {
+		if flow.flowType == flowType {
+			return flow, true
+		}
+	}
+	return OAuth2Flow{}, false
+}
+
+// tokenFlow is the flow the generated token store uses to fetch and refresh
+// tokens. Flows the store can complete on it's own are preferred.
+func (o OAuth2) tokenFlow() OAuth2Flow {
+	for _, flowType := range []OAuth2FlowType{ClientCredentialsFlow, PasswordFlow, AuthorizationCodeFlow, ImplicitFlow} {
+		if flow, ok := o.Flow(flowType); ok {
+			return flow
+		}
+	}
+	return OAuth2Flow{}
+}
+
+func (o OAuth2) clientIDVariable() string {
+	return convention.TitleCase(o.Identifier()) + "ClientId"
+}
+
+func (o OAuth2) clientSecretVariable() string {
+	return convention.TitleCase(o.Identifier()) + "ClientSecret"
+}
+
+func (o OAuth2) redirectURIVariable() string {
+	return convention.TitleCase(o.Identifier()) + "RedirectUri"
+}
+
+// Variables are the client credentials sent when requesting tokens, and the
+// redirect URI players are sent back to when logging in
+func (o OAuth2) Variables() []Variable {
+	variables := []Variable{
+		NewVariable(o.clientIDVariable(), fmt.Sprintf("Client ID used when requesting %s tokens", convention.TitleCase(o.Identifier())), false),
+		NewVariable(o.clientSecretVariable(), fmt.Sprintf("Client secret used when requesting %s tokens", convention.TitleCase(o.Identifier())), true),
+	}
+	if _, ok := o.Flow(AuthorizationCodeFlow); ok {
+		variables = append(variables, NewVariable(o.redirectURIVariable(), fmt.Sprintf("Where players are sent back to after logging in through %s", convention.TitleCase(o.Identifier())), false))
+	}
+	return variables
+}
+
+// CredentialsAvailable checks whether the token store has a token or knows
+// how to get one
+func (o OAuth2) CredentialsAvailable() string {
+	return fmt.Sprintf("this.Config.%s.CanAuthorize", o.StateName())
+}
+
+// ModifyNetworkRequest attaches whatever valid token the store currently has.
+// The store gets a chance to fetch a new one before the request is sent.
+func (o OAuth2) ModifyNetworkRequest() string {
+	return fmt.Sprintf("this.Config.%s.ApplyToken(unityNetworkReq);", o.StateName())
+}
+
+// StateName is the config property holding the definition's token store
+func (o OAuth2) StateName() string {
+	return convention.TitleCase(o.Identifier()) + "Tokens"
+}
+
+// LoginName is the config property holding the definition's authorization
+// code login, if it has one
+func (o OAuth2) LoginName() string {
+	return convention.TitleCase(o.Identifier()) + "Login"
+}
+
+// ConfigMembers is the token store, along with a PKCE login when the
+// definition has an authorization code flow
+func (o OAuth2) ConfigMembers() []ConfigMember {
+	flow := o.tokenFlow()
+	members := []ConfigMember{
+		NewConfigMember(
+			o.StateName(),
+			"OAuthTokenStore",
+			fmt.Sprintf(
+				"new OAuthTokenStore(%q, %q, %q, %q, () => this.%s.%s, () => this.%s.%s)",
+				flow.TokenURL(),
+				flow.RefreshURL(),
+				strings.Join(flow.ScopeNames(), " "),
+				flow.grantType(),
+				CredentialProviderProperty(o.Identifier()),
+				o.clientIDVariable(),
+				CredentialProviderProperty(o.Identifier()),
+				o.clientSecretVariable(),
+			),
+			o.String(),
+		),
+	}
+
+	if codeFlow, ok := o.Flow(AuthorizationCodeFlow); ok {
+		members = append(members, NewConfigMember(
+			o.LoginName(),
+			"OAuthPKCELogin",
+			fmt.Sprintf(
+				"new OAuthPKCELogin(%q, %q, %q, this.%s, () => this.%s.%s)",
+				codeFlow.AuthorizationURL(),
+				codeFlow.TokenURL(),
+				strings.Join(codeFlow.ScopeNames(), " "),
+				o.StateName(),
+				CredentialProviderProperty(o.Identifier()),
+				o.redirectURIVariable(),
+			),
+			fmt.Sprintf("Logs players in through %s's authorization code flow", convention.TitleCase(o.Identifier())),
+		))
+	}
+
+	return members
+}
+
+// SupportingClasses is the token and the token store, along with the PKCE
+// login when the definition has an authorization code flow
+func (o OAuth2) SupportingClasses() []string {
+	if _, ok := o.Flow(AuthorizationCodeFlow); ok {
+		return []string{oauthTokenStoreClasses, oauthPKCELoginClass}
+	}
+	return []string{oauthTokenStoreClasses}
+}
+
+func (o OAuth2) String() string {
+	flows := make([]string, len(o.flows))
+	for i, flow := range o.flows {
+		flows[i] = string(flow.flowType)
+	}
+	return fmt.Sprintf("%s is OAuth2 using the %s flow", convention.TitleCase(o.Identifier()), strings.Join(flows, ", "))
+}
+
+const oauthTokenStoreClasses = `// OAuthToken is what an OAuth2 token endpoint responds with
+public class OAuthToken {
+
+	[JsonProperty("access_token")]
+	public string AccessToken;
+
+	[JsonProperty("token_type")]
+	public string TokenType;
+
+	[JsonProperty("expires_in")]
+	public long? ExpiresIn;
+
+	[JsonProperty("refresh_token")]
+	public string RefreshToken;
+
+	[JsonProperty("scope")]
+	public string Scope;
+
+	// Only sent by OpenID Connect providers
+	[JsonProperty("id_token")]
+	public string IdToken;
+
+}
+
+// OAuthTokenStore keeps track of the token used to authorize requests for an
+// OAuth2 security definition, fetching and refreshing it as needed
+public class OAuthTokenStore : IRequestAuthorizer {
+
+	// Tokens are considered expired a little early to account for the time a
+	// request spends in flight
+	private const int expiryLeewaySeconds = 30;
+
+	private string tokenUrl;
+
+	private string refreshUrl;
+
+	private System.Func<string> clientId;
+
+	private System.Func<string> clientSecret;
+
+	private bool fetching;
+
+	// The grant used when a new token is needed (client_credentials or
+	// password). When empty tokens have to be provided through SetToken.
+	public string GrantType { get; set; }
+
+	// Space separated scopes requested with new tokens
+	public string Scopes { get; set; }
+
+	// Username sent with the password grant
+	public string Username { get; set; }
+
+	// Password sent with the password grant
+	public string Password { get; set; }
+
+	public OAuthToken Token { get; private set; }
+
+	// When the current token stops being valid
+	public System.DateTime ExpiresAt { get; private set; }
+
+	// What went wrong the last time a token was requested, if anything
+	public string Error { get; private set; }
+
+	public OAuthTokenStore(string tokenUrl, string refreshUrl, string scopes, string grantType, System.Func<string> clientId, System.Func<string> clientSecret) {
+		this.tokenUrl = tokenUrl;
+		this.refreshUrl = refreshUrl;
+		this.Scopes = scopes;
+		this.GrantType = grantType;
+		this.clientId = clientId;
+		this.clientSecret = clientSecret;
+	}
+
+	public string ClientId { get { return clientId(); } }
+
+	public string TokenUrl { get { return tokenUrl; } set { tokenUrl = value; } }
+
+	public string RefreshUrl { get { return refreshUrl; } set { refreshUrl = value; } }
+
+	public bool HasValidToken { get { return Token != null && string.IsNullOrEmpty(Token.AccessToken) == false && System.DateTime.UtcNow < ExpiresAt; } }
+
+	public bool CanRefresh { get { return Token != null && string.IsNullOrEmpty(Token.RefreshToken) == false; } }
+
+	// Whether or not the store has a token or knows how to get one
+	public bool CanAuthorize {
+		get {
+			if (HasValidToken || CanRefresh) {
+				return true;
+			}
+			switch (GrantType) {
+				case "client_credentials":
+					return string.IsNullOrEmpty(clientId()) == false;
+				case "password":
+					return string.IsNullOrEmpty(Username) == false;
+			}
+			return false;
+		}
+	}
+
+	public void SetToken(OAuthToken token) {
+		Token = token;
+		if (token != null && token.ExpiresIn.HasValue) {
+			ExpiresAt = System.DateTime.UtcNow.AddSeconds(System.Math.Max(0, token.ExpiresIn.Value - expiryLeewaySeconds));
+		} else {
+			ExpiresAt = System.DateTime.MaxValue;
+		}
+	}
+
+	// Invalidate marks the current token as expired so it gets refreshed
+	// before the next request
+	public void Invalidate() {
+		ExpiresAt = System.DateTime.MinValue;
+	}
+
+	public void Clear() {
+		Token = null;
+		ExpiresAt = System.DateTime.MinValue;
+	}
+
+	public IEnumerator FetchWithClientCredentials() {
+		var form = new System.Collections.Generic.Dictionary<string, string>();
+		form["grant_type"] = "client_credentials";
+		yield return RequestToken(tokenUrl, form);
+	}
+
+	public IEnumerator FetchWithPassword(string username, string password) {
+		var form = new System.Collections.Generic.Dictionary<string, string>();
+		form["grant_type"] = "password";
+		form["username"] = username;
+		form["password"] = password;
+		yield return RequestToken(tokenUrl, form);
+	}
+
+	public IEnumerator Refresh() {
+		if (CanRefresh == false) {
+			yield break;
+		}
+
+		var refreshToken = Token.RefreshToken;
+		var form = new System.Collections.Generic.Dictionary<string, string>();
+		form["grant_type"] = "refresh_token";
+		form["refresh_token"] = refreshToken;
+		yield return RequestToken(string.IsNullOrEmpty(refreshUrl) ? tokenUrl : refreshUrl, form);
+
+		if (HasValidToken == false) {
+			// The refresh token is no good, stop trying to use it
+			Clear();
+		} else if (string.IsNullOrEmpty(Token.RefreshToken)) {
+			Token.RefreshToken = refreshToken;
+		}
+	}
+
+	// ExchangeAuthorizationCode trades the code an authorization server
+	// redirected with for a token
+	public IEnumerator ExchangeAuthorizationCode(string url, string code, string codeVerifier, string redirectUri) {
+		var form = new System.Collections.Generic.Dictionary<string, string>();
+		form["grant_type"] = "authorization_code";
+		form["code"] = code;
+		form["code_verifier"] = codeVerifier;
+		form["redirect_uri"] = redirectUri;
+		yield return RequestToken(url, form);
+	}
+
+	// EnsureToken makes sure the store has a valid token, refreshing or
+	// fetching a new one when it doesn't
+	public IEnumerator EnsureToken() {
+		while (fetching) {
+			yield return null;
+		}
+
+		if (HasValidToken) {
+			yield break;
+		}
+
+		if (CanRefresh) {
+			yield return Refresh();
+			if (HasValidToken) {
+				yield break;
+			}
+		}
+
+		switch (GrantType) {
+			case "client_credentials":
+				yield return FetchWithClientCredentials();
+				break;
+			case "password":
+				yield return FetchWithPassword(Username, Password);
+				break;
+		}
+	}
+
+	public void ApplyToken(UnityWebRequest req) {
+		if (HasValidToken) {
+			req.SetRequestHeader("Authorization", "Bearer " + Token.AccessToken);
+		}
+	}
+
+	public IEnumerator Authorize(UnityWebRequest req) {
+		yield return EnsureToken();
+		ApplyToken(req);
+	}
+
+	public void Inspect(UnityWebRequest req) {
+		if (req.responseCode == 401) {
+			Invalidate();
+		}
+	}
+
+	private IEnumerator RequestToken(string url, System.Collections.Generic.Dictionary<string, string> form) {
+		fetching = true;
+		if (string.IsNullOrEmpty(Scopes) == false && (form["grant_type"] == "client_credentials" || form["grant_type"] == "password")) {
+			form["scope"] = Scopes;
+		}
+		if (string.IsNullOrEmpty(clientId()) == false) {
+			form["client_id"] = clientId();
+		}
+		if (string.IsNullOrEmpty(clientSecret()) == false) {
+			form["client_secret"] = clientSecret();
+		}
+
+		using (var req = UnityWebRequest.Post(url, form)) {
+			req.SetRequestHeader("Accept", "application/json");
+			yield return req.SendWebRequest();
+
+			if (req.responseCode >= 200 && req.responseCode < 300) {
+				try {
+					SetToken(JsonConvert.DeserializeObject<OAuthToken>(req.downloadHandler.text));
+					Error = null;
+				} catch (JsonException e) {
+					Error = e.Message;
+				}
+			} else {
+				Error = string.IsNullOrEmpty(req.error) ? req.downloadHandler.text : req.error;
+			}
+		}
+		fetching = false;
+	}
+
+}`
+
+const oauthPKCELoginClass = `// OAuthPKCELogin has a player log in through an OAuth2 authorization code flow
+// secured with PKCE. The redirect is captured by listening on localhost in
+// desktop builds, and through a deep link in mobile builds.
+public class OAuthPKCELogin {
+
+	// Used in desktop builds when no redirect URI has been configured
+	public const string DefaultDesktopRedirectUri = "http://127.0.0.1:8976/callback/";
+
+	private string authorizationUrl;
+
+	private string tokenUrl;
+
+	private OAuthTokenStore tokenStore;
+
+	private System.Func<string> redirectUri;
+
+	private string redirectedTo;
+
+	// Space separated scopes requested when logging in
+	public string Scopes { get; set; }
+
+	// How long to wait on the player to finish logging in
+	public float TimeoutSeconds { get; set; }
+
+	// What went wrong the last time the player tried logging in, if anything
+	public string Error { get; private set; }
+
+	public OAuthPKCELogin(string authorizationUrl, string tokenUrl, string scopes, OAuthTokenStore tokenStore, System.Func<string> redirectUri) {
+		this.authorizationUrl = authorizationUrl;
+		this.tokenUrl = tokenUrl;
+		this.Scopes = scopes;
+		this.tokenStore = tokenStore;
+		this.redirectUri = redirectUri;
+		this.TimeoutSeconds = 300;
+	}
+
+	public string AuthorizationUrl { get { return authorizationUrl; } set { authorizationUrl = value; } }
+
+	public string TokenUrl { get { return tokenUrl; } set { tokenUrl = value; } }
+
+	public string RedirectUri {
+		get {
+			var configured = redirectUri();
+			return string.IsNullOrEmpty(configured) ? DefaultDesktopRedirectUri : configured;
+		}
+	}
+
+	public static string GenerateCodeVerifier() {
+		var bytes = new byte[32];
+		using (var rng = System.Security.Cryptography.RandomNumberGenerator.Create()) {
+			rng.GetBytes(bytes);
+		}
+		return Base64Url(bytes);
+	}
+
+	public static string CodeChallenge(string codeVerifier) {
+		using (var sha = System.Security.Cryptography.SHA256.Create()) {
+			return Base64Url(sha.ComputeHash(Encoding.ASCII.GetBytes(codeVerifier)));
+		}
+	}
+
+	private static string Base64Url(byte[] bytes) {
+		return System.Convert.ToBase64String(bytes).TrimEnd('=').Replace('+', '-').Replace('/', '_');
+	}
+
+	public string BuildAuthorizeUrl(string codeChallenge, string state) {
+		var url = authorizationUrl + (authorizationUrl.Contains("?") ? "&" : "?");
+		url += "response_type=code";
+		url += "&client_id=" + UnityWebRequest.EscapeURL(tokenStore.ClientId ?? "");
+		url += "&redirect_uri=" + UnityWebRequest.EscapeURL(RedirectUri);
+		url += "&code_challenge=" + codeChallenge;
+		url += "&code_challenge_method=S256";
+		url += "&state=" + state;
+		if (string.IsNullOrEmpty(Scopes) == false) {
+			url += "&scope=" + UnityWebRequest.EscapeURL(Scopes);
+		}
+		return url;
+	}
+
+	// Login opens the authorization URL in the player's browser, waits for
+	// them to be redirected back, and exchanges the code for a token
+	public IEnumerator Login() {
+		Error = null;
+		redirectedTo = null;
+
+		var codeVerifier = GenerateCodeVerifier();
+		var state = GenerateCodeVerifier();
+		var authorizeUrl = BuildAuthorizeUrl(CodeChallenge(codeVerifier), state);
+
+#if (UNITY_IOS || UNITY_ANDROID) && !UNITY_EDITOR
+		if (string.IsNullOrEmpty(redirectUri())) {
+			Error = "a redirect URI registered as a deep link is required to log in on mobile";
+			yield break;
+		}
+		Application.deepLinkActivated += OnDeepLinkActivated;
+		Application.OpenURL(authorizeUrl);
+		var startedAt = Time.realtimeSinceStartup;
+		while (redirectedTo == null && Time.realtimeSinceStartup - startedAt < TimeoutSeconds) {
+			yield return null;
+		}
+		Application.deepLinkActivated -= OnDeepLinkActivated;
+#elif UNITY_WEBGL && !UNITY_EDITOR
+		Error = "logging in through a redirect is not supported in WebGL builds";
+		yield break;
+#else
+		yield return ListenForRedirect(authorizeUrl);
+#endif
+
+		if (redirectedTo == null) {
+			if (Error == null) {
+				Error = "timed out waiting on the player to log in";
+			}
+			yield break;
+		}
+
+		var query = ParseQuery(redirectedTo);
+		string value;
+		if (query.TryGetValue("error", out value)) {
+			Error = value;
+			yield break;
+		}
+
+		if (query.TryGetValue("state", out value) == false || value != state) {
+			Error = "state returned by the authorization server does not match the one sent";
+			yield break;
+		}
+
+		if (query.TryGetValue("code", out value) == false) {
+			Error = "no authorization code found in the redirect";
+			yield break;
+		}
+
+		yield return tokenStore.ExchangeAuthorizationCode(tokenUrl, value, codeVerifier, RedirectUri);
+		Error = tokenStore.Error;
+	}
+
+	private void OnDeepLinkActivated(string url) {
+		if (url.StartsWith(RedirectUri)) {
+			redirectedTo = url;
+		}
+	}
+
+	private IEnumerator ListenForRedirect(string authorizeUrl) {
+		var listener = new System.Net.HttpListener();
+		try {
+			listener.Prefixes.Add(RedirectUri.EndsWith("/") ? RedirectUri : RedirectUri + "/");
+			listener.Start();
+		} catch (System.Exception e) {
+			Error = e.Message;
+		}
+
+		if (Error != null) {
+			listener.Close();
+			yield break;
+		}
+
+		var contextTask = listener.GetContextAsync();
+		Application.OpenURL(authorizeUrl);
+		var startedAt = Time.realtimeSinceStartup;
+		while (contextTask.IsCompleted == false && Time.realtimeSinceStartup - startedAt < TimeoutSeconds) {
+			yield return null;
+		}
+
+		if (contextTask.Status == System.Threading.Tasks.TaskStatus.RanToCompletion) {
+			var context = contextTask.Result;
+			redirectedTo = context.Request.Url.ToString();
+
+			var page = Encoding.UTF8.GetBytes("<html><body>You can close this window and return to the game.</body></html>");
+			context.Response.ContentType = "text/html";
+			context.Response.ContentLength64 = page.Length;
+			context.Response.OutputStream.Write(page, 0, page.Length);
+			context.Response.OutputStream.Close();
+		}
+		listener.Close();
+	}
+
+	private static System.Collections.Generic.Dictionary<string, string> ParseQuery(string url) {
+		var query = new System.Collections.Generic.Dictionary<string, string>();
+		var start = url.IndexOf('?');
+		if (start < 0) {
+			return query;
+		}
+
+		var end = url.IndexOf('#', start);
+		var queryString = end < 0 ? url.Substring(start + 1) : url.Substring(start + 1, end - start - 1);
+		foreach (var pair in queryString.Split('&')) {
+			if (pair == "") {
+				continue;
+			}
+			var split = pair.IndexOf('=');
+			if (split < 0) {
+				query[UnityWebRequest.UnEscapeURL(pair)] = "";
+			} else {
+				query[UnityWebRequest.UnEscapeURL(pair.Substring(0, split))] = UnityWebRequest.UnEscapeURL(pair.Substring(split + 1));
+			}
+		}
+		return query;
+	}
+
+}`
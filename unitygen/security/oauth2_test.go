@@ -0,0 +1,98 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OAuth2(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewOAuth2("petstoreAuth", []security.OAuth2Flow{
+		security.NewOAuth2Flow(security.ImplicitFlow, "https://example.com/authorize", "", "", nil),
+		security.NewOAuth2Flow(security.ClientCredentialsFlow, "", "https://example.com/token", "https://example.com/refresh", map[string]string{
+			"write:pets": "modify pets",
+			"read:pets":  "read pets",
+		}),
+	})
+
+	// ********************************** ACT *********************************
+	id := guard.Identifier()
+	variables := guard.Variables()
+	available := guard.CredentialsAvailable()
+	modifier := guard.ModifyNetworkRequest()
+	members := guard.ConfigMembers()
+	str := guard.String()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "petstoreAuth", id)
+	if assert.Len(t, variables, 2) {
+		assert.Equal(t, "PetstoreAuthClientId", variables[0].Name())
+		assert.False(t, variables[0].Secret())
+		assert.Equal(t, "PetstoreAuthClientSecret", variables[1].Name())
+		assert.True(t, variables[1].Secret())
+	}
+	assert.Equal(t, "this.Config.PetstoreAuthTokens.CanAuthorize", available)
+	assert.Equal(t, "this.Config.PetstoreAuthTokens.ApplyToken(unityNetworkReq);", modifier)
+	assert.Equal(t, "PetstoreAuthTokens", guard.StateName())
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, "PetstoreAuthTokens", members[0].Name())
+		assert.Equal(t, "OAuthTokenStore", members[0].Type())
+		assert.Equal(t, `new OAuthTokenStore("https://example.com/token", "https://example.com/refresh", "read:pets write:pets", "client_credentials", () => this.PetstoreAuthCredentials.PetstoreAuthClientId, () => this.PetstoreAuthCredentials.PetstoreAuthClientSecret)`, members[0].Initializer())
+		assert.Equal(t, str, members[0].Description())
+	}
+	assert.Equal(t, "PetstoreAuth is OAuth2 using the clientCredentials, implicit flow", str)
+	if assert.Len(t, guard.SupportingClasses(), 1) {
+		assert.Contains(t, guard.SupportingClasses()[0], "public class OAuthTokenStore : IRequestAuthorizer {")
+	}
+}
+
+func Test_OAuth2PrefersFlowsItCanCompleteOnItsOwn(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewOAuth2("auth", []security.OAuth2Flow{
+		security.NewOAuth2Flow(security.AuthorizationCodeFlow, "https://example.com/authorize", "https://example.com/code-token", "", nil),
+		security.NewOAuth2Flow(security.PasswordFlow, "", "https://example.com/token", "", nil),
+	})
+
+	// ********************************** ACT *********************************
+	members := guard.ConfigMembers()
+
+	// ********************************* ASSERT *******************************
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, `new OAuthTokenStore("https://example.com/token", "", "", "password", () => this.AuthCredentials.AuthClientId, () => this.AuthCredentials.AuthClientSecret)`, members[0].Initializer())
+	}
+}
+
+func Test_OAuth2AuthorizationCodeGetsPKCELogin(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewOAuth2("playerAuth", []security.OAuth2Flow{
+		security.NewOAuth2Flow(security.AuthorizationCodeFlow, "https://example.com/authorize", "https://example.com/token", "", map[string]string{
+			"profile": "Read the player's profile",
+			"openid":  "Sign in",
+		}),
+	})
+
+	// ********************************** ACT *********************************
+	variables := guard.Variables()
+	members := guard.ConfigMembers()
+	classes := guard.SupportingClasses()
+
+	// ********************************* ASSERT *******************************
+	if assert.Len(t, variables, 3) {
+		assert.Equal(t, "PlayerAuthRedirectUri", variables[2].Name())
+		assert.False(t, variables[2].Secret())
+	}
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, `new OAuthTokenStore("https://example.com/token", "", "openid profile", "", () => this.PlayerAuthCredentials.PlayerAuthClientId, () => this.PlayerAuthCredentials.PlayerAuthClientSecret)`, members[0].Initializer())
+		assert.Equal(t, "PlayerAuthLogin", members[1].Name())
+		assert.Equal(t, "OAuthPKCELogin", members[1].Type())
+		assert.Equal(t, `new OAuthPKCELogin("https://example.com/authorize", "https://example.com/token", "openid profile", this.PlayerAuthTokens, () => this.PlayerAuthCredentials.PlayerAuthRedirectUri)`, members[1].Initializer())
+		assert.Equal(t, "Logs players in through PlayerAuth's authorization code flow", members[1].Description())
+	}
+	if assert.Len(t, classes, 2) {
+		assert.Contains(t, classes[1], "public class OAuthPKCELogin {")
+		assert.Contains(t, classes[1], "Application.OpenURL(authorizeUrl);")
+		assert.Contains(t, classes[1], "code_challenge_method=S256")
+	}
+}
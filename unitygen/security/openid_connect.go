@@ -0,0 +1,300 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// OpenIDConnect is a guard on a route that requires a token from an OpenID
+// Connect provider, whose endpoints are found through its discovery document
+type OpenIDConnect struct {
+	identifier string
+
+	// Where the provider's .well-known/openid-configuration document lives
+	discoveryURL string
+
+	// Scopes the spec's security requirements ask for, requested along with
+	// openid when the player logs in
+	scopes []string
+}
+
+// NewOpenIDConnect creates a new OpenID Connect definition
+func NewOpenIDConnect(identifier, discoveryURL string, scopes []string) OpenIDConnect {
+	return OpenIDConnect{
+		identifier:   identifier,
+		discoveryURL: discoveryURL,
+		scopes:       scopes,
+	}
+}
+
+// Identifier returns a unique string that represents how the swagger file
+// refers to the definition
+func (o OpenIDConnect) Identifier() string {
+	return o.identifier
+}
+
+// DiscoveryURL is where the provider's discovery document lives
+func (o OpenIDConnect) DiscoveryURL() string {
+	return o.discoveryURL
+}
+
+// Scopes is the space separated list of scopes requested when logging in,
+// which always starts with the openid scope OpenID Connect requires
+func (o OpenIDConnect) Scopes() string {
+	scopes := []string{"openid"}
+	for _, scope := range o.scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
+
+func (o OpenIDConnect) clientIDVariable() string {
+	return convention.TitleCase(o.Identifier()) + "ClientId"
+}
+
+func (o OpenIDConnect) clientSecretVariable() string {
+	return convention.TitleCase(o.Identifier()) + "ClientSecret"
+}
+
+func (o OpenIDConnect) redirectURIVariable() string {
+	return convention.TitleCase(o.Identifier()) + "RedirectUri"
+}
+
+// Variables are the client credentials and where players get redirected to
+// after logging in
+func (o OpenIDConnect) Variables() []Variable {
+	return []Variable{
+		NewVariable(o.clientIDVariable(), fmt.Sprintf("Client ID used when requesting %s tokens", convention.TitleCase(o.Identifier())), false),
+		NewVariable(o.clientSecretVariable(), fmt.Sprintf("Client secret used when requesting %s tokens", convention.TitleCase(o.Identifier())), true),
+		NewVariable(o.redirectURIVariable(), fmt.Sprintf("Where players are redirected to after logging in through %s", convention.TitleCase(o.Identifier())), false),
+	}
+}
+
+// CredentialsAvailable checks whether or not the player has logged in
+func (o OpenIDConnect) CredentialsAvailable() string {
+	return fmt.Sprintf("this.Config.%s.CanAuthorize", o.StateName())
+}
+
+// ModifyNetworkRequest generates C# code that attaches the current access
+// token, if there is one. Requests wait on the provider to refresh it first.
+func (o OpenIDConnect) ModifyNetworkRequest() string {
+	return fmt.Sprintf("this.Config.%s.Tokens.ApplyToken(unityNetworkReq);", o.StateName())
+}
+
+// StateName is the config member keeping track of the discovery document and
+// tokens
+func (o OpenIDConnect) StateName() string {
+	return convention.TitleCase(o.Identifier()) + "Connect"
+}
+
+// ConfigMembers is the OpenID Connect client
+func (o OpenIDConnect) ConfigMembers() []ConfigMember {
+	return []ConfigMember{
+		NewConfigMember(
+			o.StateName(),
+			"OpenIDConnect",
+			fmt.Sprintf(
+				"new OpenIDConnect(%q, %q, () => this.%s.%s, () => this.%s.%s, () => this.%s.%s)",
+				o.discoveryURL,
+				o.Scopes(),
+				CredentialProviderProperty(o.Identifier()),
+				o.clientIDVariable(),
+				CredentialProviderProperty(o.Identifier()),
+				o.clientSecretVariable(),
+				CredentialProviderProperty(o.Identifier()),
+				o.redirectURIVariable(),
+			),
+			o.String(),
+		),
+	}
+}
+
+// SupportingClasses is the OAuth2 token machinery, along with the discovery
+// client built on top of it
+func (o OpenIDConnect) SupportingClasses() []string {
+	return []string{oauthTokenStoreClasses, oauthPKCELoginClass, openIDConnectClasses}
+}
+
+func (o OpenIDConnect) String() string {
+	return fmt.Sprintf("%s is OpenID Connect discovered through %s", convention.TitleCase(o.Identifier()), o.discoveryURL)
+}
+
+const openIDConnectClasses = `// OpenIDConfiguration is the part of an OpenID Connect discovery document
+// needed to log players in and fetch tokens
+public class OpenIDConfiguration {
+
+	[JsonProperty("issuer")]
+	public string Issuer;
+
+	[JsonProperty("authorization_endpoint")]
+	public string AuthorizationEndpoint;
+
+	[JsonProperty("token_endpoint")]
+	public string TokenEndpoint;
+
+	[JsonProperty("userinfo_endpoint")]
+	public string UserinfoEndpoint;
+
+	[JsonProperty("jwks_uri")]
+	public string JwksUri;
+
+}
+
+// OpenIDConnect fetches and caches an OpenID Connect provider's discovery
+// document, and uses the endpoints found in it to log players in and keep
+// their tokens fresh
+public class OpenIDConnect : IRequestAuthorizer {
+
+	// Discovery documents rarely change, so they're shared between every
+	// client pointed at the same provider
+	private static System.Collections.Generic.Dictionary<string, OpenIDConfiguration> discovered = new System.Collections.Generic.Dictionary<string, OpenIDConfiguration>();
+
+	private string discoveryUrl;
+
+	public OAuthTokenStore Tokens { get; private set; }
+
+	public OAuthPKCELogin Login { get; private set; }
+
+	public OpenIDConfiguration Configuration { get; private set; }
+
+	// What went wrong the last time the discovery document was fetched or the
+	// ID token was validated, if anything
+	public string Error { get; private set; }
+
+	public OpenIDConnect(string discoveryUrl, string scopes, System.Func<string> clientId, System.Func<string> clientSecret, System.Func<string> redirectUri) {
+		this.discoveryUrl = discoveryUrl;
+
+		// Tokens only come from players logging in, so there's no grant type
+		// for the store to fetch them with on it's own
+		this.Tokens = new OAuthTokenStore("", "", scopes, "", clientId, clientSecret);
+		this.Login = new OAuthPKCELogin("", "", scopes, this.Tokens, redirectUri);
+	}
+
+	public bool CanAuthorize { get { return Tokens.HasValidToken || Tokens.CanRefresh; } }
+
+	// Discover fetches the discovery document unless it's already been fetched
+	public IEnumerator Discover() {
+		if (Configuration != null) {
+			yield break;
+		}
+
+		OpenIDConfiguration cached;
+		if (discovered.TryGetValue(discoveryUrl, out cached)) {
+			Use(cached);
+			yield break;
+		}
+
+		using (var req = UnityWebRequest.Get(discoveryUrl)) {
+			req.SetRequestHeader("Accept", "application/json");
+			yield return req.SendWebRequest();
+
+			if (req.responseCode < 200 || req.responseCode >= 300) {
+				Error = string.IsNullOrEmpty(req.error) ? req.downloadHandler.text : req.error;
+				yield break;
+			}
+
+			try {
+				var configuration = JsonConvert.DeserializeObject<OpenIDConfiguration>(req.downloadHandler.text);
+				if (configuration == null || string.IsNullOrEmpty(configuration.TokenEndpoint)) {
+					Error = "discovery document has no token endpoint";
+					yield break;
+				}
+				discovered[discoveryUrl] = configuration;
+				Use(configuration);
+			} catch (JsonException e) {
+				Error = e.Message;
+			}
+		}
+	}
+
+	private void Use(OpenIDConfiguration configuration) {
+		Configuration = configuration;
+		Tokens.TokenUrl = configuration.TokenEndpoint;
+		Login.AuthorizationUrl = configuration.AuthorizationEndpoint;
+		Login.TokenUrl = configuration.TokenEndpoint;
+		Error = null;
+	}
+
+	// LogIn has the player log in through the provider's authorization
+	// endpoint, and makes sure the ID token that comes back hasn't expired
+	public IEnumerator LogIn() {
+		yield return Discover();
+		if (Configuration == null) {
+			yield break;
+		}
+
+		yield return Login.Login();
+		if (Login.Error != null) {
+			Error = Login.Error;
+			yield break;
+		}
+
+		if (HasValidIdToken == false) {
+			Tokens.Clear();
+			Error = "the ID token returned by the provider has expired";
+		}
+	}
+
+	public string IdToken { get { return Tokens.Token == null ? null : Tokens.Token.IdToken; } }
+
+	// When the ID token's exp claim says it stops being valid, if it has one
+	public System.DateTime? IdTokenExpiresAt {
+		get {
+			if (string.IsNullOrEmpty(IdToken)) {
+				return null;
+			}
+
+			var parts = IdToken.Split('.');
+			if (parts.Length < 2) {
+				return null;
+			}
+
+			var payload = parts[1].Replace('-', '+').Replace('_', '/');
+			payload = payload.PadRight(payload.Length + (4 - payload.Length % 4) % 4, '=');
+			try {
+				var claims = Newtonsoft.Json.Linq.JObject.Parse(Encoding.UTF8.GetString(System.Convert.FromBase64String(payload)));
+				var exp = claims["exp"];
+				if (exp == null) {
+					return null;
+				}
+				return new System.DateTime(1970, 1, 1, 0, 0, 0, System.DateTimeKind.Utc).AddSeconds((long)exp);
+			} catch (System.FormatException) {
+				return null;
+			} catch (JsonException) {
+				return null;
+			}
+		}
+	}
+
+	// Whether or not there's an ID token whose exp claim is still in the
+	// future. Tokens without an exp claim are considered invalid.
+	public bool HasValidIdToken {
+		get {
+			var expiresAt = IdTokenExpiresAt;
+			return expiresAt.HasValue && System.DateTime.UtcNow < expiresAt.Value;
+		}
+	}
+
+	public IEnumerator Authorize(UnityWebRequest req) {
+		yield return Discover();
+		if (Configuration == null) {
+			yield break;
+		}
+
+		// Refreshing also gets a new ID token
+		if (string.IsNullOrEmpty(IdToken) == false && HasValidIdToken == false) {
+			Tokens.Invalidate();
+		}
+		yield return Tokens.EnsureToken();
+		Tokens.ApplyToken(req);
+	}
+
+	public void Inspect(UnityWebRequest req) {
+		Tokens.Inspect(req);
+	}
+
+}`
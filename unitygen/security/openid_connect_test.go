@@ -0,0 +1,80 @@
+package security_test
+
+import (
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OpenIDConnect(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewOpenIDConnect("playerAuth", "https://example.com/.well-known/openid-configuration", nil)
+
+	// ********************************** ACT *********************************
+	id := guard.Identifier()
+	variables := guard.Variables()
+	available := guard.CredentialsAvailable()
+	modifier := guard.ModifyNetworkRequest()
+	members := guard.ConfigMembers()
+	classes := guard.SupportingClasses()
+	str := guard.String()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "playerAuth", id)
+	assert.Equal(t, "https://example.com/.well-known/openid-configuration", guard.DiscoveryURL())
+	assert.Equal(t, "openid", guard.Scopes())
+	if assert.Len(t, variables, 3) {
+		assert.Equal(t, "PlayerAuthClientId", variables[0].Name())
+		assert.False(t, variables[0].Secret())
+		assert.Equal(t, "PlayerAuthClientSecret", variables[1].Name())
+		assert.True(t, variables[1].Secret())
+		assert.Equal(t, "PlayerAuthRedirectUri", variables[2].Name())
+		assert.False(t, variables[2].Secret())
+	}
+	assert.Equal(t, "this.Config.PlayerAuthConnect.CanAuthorize", available)
+	assert.Equal(t, "this.Config.PlayerAuthConnect.Tokens.ApplyToken(unityNetworkReq);", modifier)
+	assert.Equal(t, "PlayerAuthConnect", guard.StateName())
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, "PlayerAuthConnect", members[0].Name())
+		assert.Equal(t, "OpenIDConnect", members[0].Type())
+		assert.Equal(t, `new OpenIDConnect("https://example.com/.well-known/openid-configuration", "openid", () => this.PlayerAuthCredentials.PlayerAuthClientId, () => this.PlayerAuthCredentials.PlayerAuthClientSecret, () => this.PlayerAuthCredentials.PlayerAuthRedirectUri)`, members[0].Initializer())
+		assert.Equal(t, str, members[0].Description())
+	}
+	assert.Equal(t, "PlayerAuth is OpenID Connect discovered through https://example.com/.well-known/openid-configuration", str)
+	if assert.Len(t, classes, 3) {
+		assert.Contains(t, classes[0], "public class OAuthTokenStore : IRequestAuthorizer {")
+		assert.Contains(t, classes[1], "public class OAuthPKCELogin {")
+		assert.Contains(t, classes[2], "public class OpenIDConnect : IRequestAuthorizer {")
+	}
+}
+
+func Test_OpenIDConnectRequestsScopesAlongWithOpenID(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	guard := security.NewOpenIDConnect("playerAuth", "https://example.com/.well-known/openid-configuration", []string{"email", "openid", "profile"})
+
+	// ********************************** ACT *********************************
+	scopes := guard.Scopes()
+	members := guard.ConfigMembers()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, "openid email profile", scopes)
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, `new OpenIDConnect("https://example.com/.well-known/openid-configuration", "openid email profile", () => this.PlayerAuthCredentials.PlayerAuthClientId, () => this.PlayerAuthCredentials.PlayerAuthClientSecret, () => this.PlayerAuthCredentials.PlayerAuthRedirectUri)`, members[0].Initializer())
+	}
+}
+
+func Test_OpenIDConnectSharesOAuth2Classes(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	oidc := security.NewOpenIDConnect("playerAuth", "https://example.com/.well-known/openid-configuration", nil)
+	oauth := security.NewOAuth2("petAuth", []security.OAuth2Flow{
+		security.NewOAuth2Flow(security.AuthorizationCodeFlow, "https://example.com/authorize", "https://example.com/token", "", nil),
+	})
+
+	// ********************************** ACT *********************************
+	oidcClasses := oidc.SupportingClasses()
+	oauthClasses := oauth.SupportingClasses()
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, oauthClasses, oidcClasses[:2])
+}
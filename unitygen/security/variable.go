@@ -0,0 +1,33 @@
+package security
+
+// Variable is a value the generated config holds on behalf of a guard, like
+// an API key or a client ID
+type Variable struct {
+	name        string
+	description string
+	secret      bool
+}
+
+// NewVariable creates a new variable for the config
+func NewVariable(name, description string, secret bool) Variable {
+	return Variable{
+		name:        name,
+		description: description,
+		secret:      secret,
+	}
+}
+
+// Name of the property on the config
+func (v Variable) Name() string {
+	return v.name
+}
+
+// Description of what the variable is used for
+func (v Variable) Description() string {
+	return v.description
+}
+
+// Secret is whether or not the value should be masked when displayed
+func (v Variable) Secret() bool {
+	return v.secret
+}
@@ -1,6 +1,7 @@
 package unitygen
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
@@ -32,17 +33,46 @@ func (s Service) Paths() []path.Path {
 	return s.paths
 }
 
+// ServiceOptions are APIs that can be generated for a service on top of the
+// coroutine based one
+type ServiceOptions struct {
+	// Async generates an awaitable version of every service function
+	Async path.AsyncFlavor
+
+	// Callbacks generates a version of every service function that reports
+	// back through onSuccess/onError delegates
+	Callbacks bool
+
+	// Fluent generates a builder for every request
+	Fluent bool
+
+	// Fakes generates an in-memory fake of every service, for testing code
+	// that uses it without a network
+	Fakes bool
+
+	// Callers generates a MonoBehaviour for every request, so it can be sent
+	// and responded to from the Inspector
+	Callers bool
+
+	// VisualScripting generates a Visual Scripting unit for every request
+	VisualScripting bool
+}
+
 // ToCSharp writes out the service as a class with collection of functions that
 // correspond to calling different routes
 func (s Service) ToCSharp(knownModifiers []security.Auth, serviceConfigName string) string {
-	className := convention.TitleCase(s.Name())
-	if strings.HasSuffix(className, "Service") == false {
-		className += "Service"
-	}
+	return s.ToCSharpWithOptions(knownModifiers, serviceConfigName, ServiceOptions{})
+}
+
+// ToCSharpWithOptions writes out the service along with whatever extra APIs
+// the options ask for
+func (s Service) ToCSharpWithOptions(knownModifiers []security.Auth, serviceConfigName string, options ServiceOptions) string {
+	className := s.className()
 
 	builder := strings.Builder{}
-	builder.WriteString("public class ")
-	builder.WriteString(className)
+	builder.WriteString(s.renderInterface(options))
+	builder.WriteString("\n\n")
+	fmt.Fprintf(&builder, "public class %s : %s", className, s.interfaceName())
 	builder.WriteString(" {\n\n\tpublic ")
 	builder.WriteString(serviceConfigName)
 	builder.WriteString(" Config { get; }\n\n\tpublic ")
@@ -52,13 +82,81 @@ func (s Service) ToCSharp(knownModifiers []security.Auth, serviceConfigName stri
 	builder.WriteString(" Config) {\n\t\tthis.Config = Config;\n\t}\n\n")
 
 	for _, p := range s.paths {
-		builder.WriteString(p.SupportingClasses())
+		builder.WriteString(p.SupportingClasses(options.Fakes))
 		builder.WriteString("\n")
 		builder.WriteString(p.ServiceFunction(knownModifiers))
 		builder.WriteString("\n")
+		if options.Async != path.NoAsync {
+			builder.WriteString(p.AsyncServiceFunction(options.Async))
+			builder.WriteString("\n")
+		}
+		if options.Callbacks {
+			builder.WriteString(p.CallbackServiceFunction())
+			builder.WriteString("\n")
+		}
+		if options.Fluent {
+			builder.WriteString(p.RequestBuilder())
+			builder.WriteString("\n")
+			builder.WriteString(p.RequestBuilderServiceFunction())
+			builder.WriteString("\n")
+		}
 	}
 
 	builder.WriteString("}")
 
+	if options.Fakes {
+		builder.WriteString("\n\n")
+		builder.WriteString(s.renderFake(options))
+	}
+
+	return builder.String()
+}
+
+func (s Service) className() string {
+	className := convention.TitleCase(s.Name())
+	if strings.HasSuffix(className, "Service") == false {
+		className += "Service"
+	}
+	return className
+}
+
+func (s Service) interfaceName() string {
+	return "I" + s.className()
+}
+
+// Caller is the MonoBehaviour that sends the path's request from the
+// Inspector, using the scriptable object config with the name given
+func (s Service) Caller(p path.Path, configName string) string {
+	return p.Caller(s.className(), configName)
+}
+
+// renderInterface declares everything the service can do, so code using it
+// can be handed a fake instead
+func (s Service) renderInterface(options ServiceOptions) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "// %s is everything %s can do\n", s.interfaceName(), s.className())
+	fmt.Fprintf(&builder, "public interface %s {\n", s.interfaceName())
+	for _, p := range s.paths {
+		builder.WriteString("\n")
+		for _, signature := range p.ServiceSignatures(s.className(), options.Async, options.Callbacks, options.Fluent) {
+			fmt.Fprintf(&builder, "\t%s;\n", signature)
+		}
+	}
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// renderFake implements the service's interface without a network, answering
+// requests with responses queued up ahead of time
+func (s Service) renderFake(options ServiceOptions) string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "// Fake%s answers requests with the responses queued up on it instead of\n", s.className())
+	builder.WriteString("// sending them, recording every call made\n")
+	fmt.Fprintf(&builder, "public class Fake%s : FakeService, %s {\n\n", s.className(), s.interfaceName())
+	for _, p := range s.paths {
+		builder.WriteString(p.FakeServiceFunctions(s.className(), options.Async, options.Callbacks, options.Fluent))
+		builder.WriteString("\n\n")
+	}
+	builder.WriteString("}")
 	return builder.String()
 }
@@ -1,9 +1,11 @@
 package unitygen_test
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/recolude/swagger-unity-codegen/unitygen"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,7 +17,11 @@ func TestServiceNoPaths(t *testing.T) {
 	code := service.ToCSharp(nil, "ServiceConfig")
 
 	// ********************************* ASSERT *******************************
-	assert.Equal(t, `public class TestService {
+	assert.Equal(t, `// ITestService is everything TestService can do
+public interface ITestService {
+}
+
+public class TestService : ITestService {
 
 	public ServiceConfig Config { get; }
 
@@ -34,7 +40,11 @@ func TestService_DoesntAppend2ndServiceToName(t *testing.T) {
 	code := service.ToCSharp(nil, "RecoludeConfig")
 
 	// ********************************* ASSERT *******************************
-	assert.Equal(t, `public class TestService {
+	assert.Equal(t, `// ITestService is everything TestService can do
+public interface ITestService {
+}
+
+public class TestService : ITestService {
 
 	public RecoludeConfig Config { get; }
 
@@ -44,3 +54,134 @@ func TestService_DoesntAppend2ndServiceToName(t *testing.T) {
 
 }`, code)
 }
+
+func TestService_AsyncFunctionsFollowEachServiceFunction(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharpWithOptions(nil, "Config", unitygen.ServiceOptions{Async: path.TaskAsync})
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `public PingUnityWebRequest Ping()
+{
+	var unityNetworkReq = new UnityWebRequest(string.Format("{0}/api/v1/ping", this.Config.BasePath), UnityWebRequest.kHttpVerbGET);
+	unityNetworkReq.timeout = this.Config.Timeout;
+	return new PingUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => Ping());
+}
+public async System.Threading.Tasks.Task<PingUnityWebRequest> PingAsync(System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken))
+{
+	return await Ping().RunAsync(cancellationToken);
+}
+}`)
+}
+
+func TestService_NoAsyncFunctionsByDefault(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharp(nil, "Config")
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, code, "PingAsync")
+}
+
+func TestService_CallbackFunctionsFollowEachServiceFunction(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharpWithOptions(nil, "Config", unitygen.ServiceOptions{Callbacks: true})
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `	return new PingUnityWebRequest(unityNetworkReq).InterceptWith(this.Config.Interceptors).RetryWith(this.Config.RetryPolicy, () => Ping());
+}
+public PingUnityWebRequest Ping(System.Action onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null)
+{
+	return WebRequestRunner.Run(Ping(), req => {`)
+}
+
+func TestService_FluentBuildersFollowEachServiceFunction(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharpWithOptions(nil, "Config", unitygen.ServiceOptions{Fluent: true})
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "public class PingRequestBuilder : IPingRequestBuilder {")
+	assert.Contains(t, code, `public IPingRequestBuilder PingBuilder()
+{
+	return new PingRequestBuilder(Ping);
+}
+}`)
+}
+
+func TestService_InterfaceDeclaresEveryServiceFunction(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharpWithOptions(nil, "Config", unitygen.ServiceOptions{Async: path.TaskAsync, Callbacks: true, Fluent: true})
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `// ITestService is everything TestService can do
+public interface ITestService {
+
+	TestService.PingUnityWebRequest Ping();
+	System.Threading.Tasks.Task<TestService.PingUnityWebRequest> PingAsync(System.Threading.CancellationToken cancellationToken = default(System.Threading.CancellationToken));
+	TestService.PingUnityWebRequest Ping(System.Action onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null);
+	TestService.IPingRequestBuilder PingBuilder();
+}
+
+public class TestService : ITestService {`)
+}
+
+func TestService_FakeImplementsTheInterface(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharpWithOptions(nil, "Config", unitygen.ServiceOptions{Callbacks: true, Fakes: true})
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `// FakeTestService answers requests with the responses queued up on it instead of
+// sending them, recording every call made
+public class FakeTestService : FakeService, ITestService {
+
+public TestService.PingUnityWebRequest Ping()
+{
+	var response = Record("Ping", null);
+	return new TestService.PingUnityWebRequest(new UnityWebRequest()).Respond(response.StatusCode, response.Body);
+}
+
+public TestService.PingUnityWebRequest Ping(System.Action onSuccess, System.Action<ApiError> onError, System.Action<float> onProgress = null)
+{
+	return FakeService.Run(Ping(), req => {`)
+}
+
+func TestService_NoFakeByDefault(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.NewService("test", []path.Path{
+		path.NewPath("/api/v1/ping", "Ping", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+	})
+
+	// ********************************** ACT *********************************
+	code := service.ToCSharp(nil, "Config")
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, code, "FakeTestService")
+}
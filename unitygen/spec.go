@@ -7,6 +7,7 @@ import (
 
 	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
 	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
 	"github.com/recolude/swagger-unity-codegen/unitygen/security"
 )
 
@@ -16,6 +17,13 @@ type Spec struct {
 	Definitions     []model.Definition
 	AuthDefinitions []security.Auth
 	Services        []Service
+
+	// Where the scriptable object config keeps secret values, serialized
+	// into the asset when left empty
+	SecretStorage SecretStorage
+
+	// Extra APIs generated for every service
+	ServiceOptions ServiceOptions
 }
 
 func NewSpec(info SpecInfo, definitions []model.Definition, authDefinitions []security.Auth, services []Service) Spec {
@@ -51,6 +59,18 @@ func (s Spec) basePathDescription() string {
 	return "The base URL to which the endpoint paths are appended"
 }
 
+func (s Spec) timeoutDescription() string {
+	return "Seconds a request waits for a response before giving up, 0 waits forever"
+}
+
+func (s Spec) retryPolicyDescription() string {
+	return "How requests that fail are retried"
+}
+
+func (s Spec) interceptorsDescription() string {
+	return "Interceptors every request and response is run through"
+}
+
 func (s Spec) renderInterfaceBody() string {
 	builder := strings.Builder{}
 
@@ -58,14 +78,45 @@ func (s Spec) renderInterfaceBody() string {
 	builder.WriteString(s.basePathDescription())
 	builder.WriteString("\n")
 	builder.WriteString("\tstring BasePath { get; }\n\n")
-	for _, authGuard := range s.AuthDefinitions {
-		fmt.Fprintf(&builder, "\t// %s\n", authGuard.String())
-		fmt.Fprintf(&builder, "\tstring %s { get; }\n\n", convention.TitleCase(authGuard.Identifier()))
+	fmt.Fprintf(&builder, "\t// %s\n", s.timeoutDescription())
+	builder.WriteString("\tint Timeout { get; }\n\n")
+	fmt.Fprintf(&builder, "\t// %s\n", s.retryPolicyDescription())
+	builder.WriteString("\tRetryPolicy RetryPolicy { get; }\n\n")
+	fmt.Fprintf(&builder, "\t// %s\n", s.interceptorsDescription())
+	builder.WriteString("\tRequestInterceptors Interceptors { get; }\n\n")
+	for _, variable := range s.configVariables() {
+		fmt.Fprintf(&builder, "\t// %s\n", variable.Description())
+		fmt.Fprintf(&builder, "\tstring %s { get; }\n\n", variable.Name())
+	}
+	for _, authGuard := range s.credentialGuards() {
+		fmt.Fprintf(&builder, "\t// %s\n", s.credentialProviderDescription(authGuard))
+		fmt.Fprintf(&builder, "\t%s %s { get; }\n\n", security.CredentialProvider(authGuard.Identifier()), security.CredentialProviderProperty(authGuard.Identifier()))
+	}
+	for _, member := range s.configMembers() {
+		fmt.Fprintf(&builder, "\t// %s\n", member.Description())
+		fmt.Fprintf(&builder, "\t%s %s { get; }\n\n", member.Type(), member.Name())
 	}
 	return builder.String()
 }
 
-func (s Spec) renderScriptableObjectBody() string {
+// storesSecretOutsideAsset is whether or not the variable is kept somewhere
+// other than the config's .asset file
+func (s Spec) storesSecretOutsideAsset(variable security.Variable) bool {
+	return variable.Secret() && s.SecretStorage.serialized() == false
+}
+
+// hasSecretsOutsideAsset is whether or not any variables are kept somewhere
+// other than the config's .asset file
+func (s Spec) hasSecretsOutsideAsset() bool {
+	for _, variable := range s.configVariables() {
+		if s.storesSecretOutsideAsset(variable) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Spec) renderScriptableObjectBody(configName string) string {
 	builder := strings.Builder{}
 
 	builder.WriteString("\t[SerializeField]\n")
@@ -75,15 +126,173 @@ func (s Spec) renderScriptableObjectBody() string {
 	builder.WriteString(s.basePathDescription())
 	builder.WriteString("\n")
 	builder.WriteString("\tpublic string BasePath { get { return basePath; } set { basePath = value; } }\n\n")
-	for _, authGuard := range s.AuthDefinitions {
 
-		privateVarName := convention.CamelCase(authGuard.Identifier())
+	builder.WriteString("\t[SerializeField]\n")
+	builder.WriteString("\tprivate int timeout;\n\n")
+	fmt.Fprintf(&builder, "\t// %s\n", s.timeoutDescription())
+	builder.WriteString("\tpublic int Timeout { get { return timeout; } set { timeout = value; } }\n\n")
+
+	builder.WriteString("\t[SerializeField]\n")
+	builder.WriteString("\tprivate RetryPolicy retryPolicy = new RetryPolicy();\n\n")
+	fmt.Fprintf(&builder, "\t// %s\n", s.retryPolicyDescription())
+	builder.WriteString("\tpublic RetryPolicy RetryPolicy { get { return retryPolicy; } set { retryPolicy = value; } }\n\n")
+
+	builder.WriteString("\t[System.NonSerialized]\n")
+	builder.WriteString("\tprivate RequestInterceptors interceptors;\n\n")
+	fmt.Fprintf(&builder, "\t// %s\n", s.interceptorsDescription())
+	builder.WriteString("\tpublic RequestInterceptors Interceptors { get { if (interceptors == null) { interceptors = new RequestInterceptors(); } return interceptors; } }\n\n")
+	for _, variable := range s.configVariables() {
+
+		if s.storesSecretOutsideAsset(variable) {
+			fmt.Fprintf(&builder, "\t// %s\n", variable.Description())
+			fmt.Fprintf(
+				&builder,
+				"\tpublic string %s { get { return %s; } set { %s; } }\n\n",
+				variable.Name(),
+				s.SecretStorage.read(configName, variable),
+				s.SecretStorage.write(configName, variable, "value"),
+			)
+			continue
+		}
+
+		privateVarName := convention.CamelCase(variable.Name())
 
 		builder.WriteString("\t[SerializeField]\n")
 		fmt.Fprintf(&builder, "\tprivate string %s;\n\n", privateVarName)
 
-		fmt.Fprintf(&builder, "\t// %s\n", authGuard.String())
-		fmt.Fprintf(&builder, "\tpublic string %s { get { return %s; } set { %s = value; } }\n\n", convention.TitleCase(authGuard.Identifier()), privateVarName, privateVarName)
+		fmt.Fprintf(&builder, "\t// %s\n", variable.Description())
+		fmt.Fprintf(&builder, "\tpublic string %s { get { return %s; } set { %s = value; } }\n\n", variable.Name(), privateVarName, privateVarName)
+	}
+	for _, authGuard := range s.credentialGuards() {
+		providerType := security.CredentialProvider(authGuard.Identifier())
+		propertyName := security.CredentialProviderProperty(authGuard.Identifier())
+		privateVarName := convention.CamelCase(propertyName)
+
+		builder.WriteString("\t[System.NonSerialized]\n")
+		fmt.Fprintf(&builder, "\tprivate %s %s;\n\n", providerType, privateVarName)
+
+		fmt.Fprintf(&builder, "\t// %s, the values set on this config unless replaced\n", s.credentialProviderDescription(authGuard))
+		fmt.Fprintf(
+			&builder,
+			"\tpublic %s %s { get { return %s ?? this; } set { %s = value; } }\n\n",
+			providerType,
+			propertyName,
+			privateVarName,
+			privateVarName,
+		)
+	}
+	for _, member := range s.configMembers() {
+
+		privateVarName := convention.CamelCase(member.Name())
+
+		builder.WriteString("\t[System.NonSerialized]\n")
+		fmt.Fprintf(&builder, "\tprivate %s %s;\n\n", member.Type(), privateVarName)
+
+		fmt.Fprintf(&builder, "\t// %s\n", member.Description())
+		fmt.Fprintf(
+			&builder,
+			"\tpublic %s %s { get { if (%s == null) { %s = %s; } return %s; } }\n\n",
+			member.Type(),
+			member.Name(),
+			privateVarName,
+			privateVarName,
+			member.Initializer(),
+			privateVarName,
+		)
+	}
+	return builder.String()
+}
+
+// configVariables are all values the different security definitions need the
+// config to hold
+func (s Spec) configVariables() []security.Variable {
+	variables := make([]security.Variable, 0)
+	for _, authGuard := range s.AuthDefinitions {
+		variables = append(variables, authGuard.Variables()...)
+	}
+	return variables
+}
+
+// credentialGuards are all security definitions that read variables through
+// a credential provider
+func (s Spec) credentialGuards() []security.Auth {
+	guards := make([]security.Auth, 0)
+	for _, authGuard := range s.AuthDefinitions {
+		if len(authGuard.Variables()) > 0 {
+			guards = append(guards, authGuard)
+		}
+	}
+	return guards
+}
+
+func (s Spec) credentialProviderDescription(authGuard security.Auth) string {
+	return fmt.Sprintf("Where %s's credentials are read from", convention.TitleCase(authGuard.Identifier()))
+}
+
+// renderCredentialProviders writes out an interface per security definition
+// for supplying its variables at runtime
+func (s Spec) renderCredentialProviders() string {
+	builder := strings.Builder{}
+	for _, authGuard := range s.credentialGuards() {
+		providerType := security.CredentialProvider(authGuard.Identifier())
+		fmt.Fprintf(&builder, "\n\n// %s supplies %s's credentials at runtime.\n", providerType, convention.TitleCase(authGuard.Identifier()))
+		builder.WriteString("// Implement IAsyncCredentialProvider too if they have to be fetched first.\n")
+		fmt.Fprintf(&builder, "public interface %s {\n\n", providerType)
+		for _, variable := range authGuard.Variables() {
+			fmt.Fprintf(&builder, "\t// %s\n", variable.Description())
+			fmt.Fprintf(&builder, "\tstring %s { get; }\n\n", variable.Name())
+		}
+		builder.WriteString("}")
+	}
+	return builder.String()
+}
+
+// requestAuthorizers are all security definitions that keep state on the
+// config
+func (s Spec) requestAuthorizers() []security.RequestAuthorizer {
+	authorizers := make([]security.RequestAuthorizer, 0)
+	for _, authGuard := range s.AuthDefinitions {
+		if authorizer, ok := authGuard.(security.RequestAuthorizer); ok {
+			authorizers = append(authorizers, authorizer)
+		}
+	}
+	return authorizers
+}
+
+// configMembers are all runtime state the different security definitions
+// keep on the config
+func (s Spec) configMembers() []security.ConfigMember {
+	members := make([]security.ConfigMember, 0)
+	for _, authorizer := range s.requestAuthorizers() {
+		members = append(members, authorizer.ConfigMembers()...)
+	}
+	return members
+}
+
+func (s Spec) renderAuthorizerClasses() string {
+	builder := strings.Builder{}
+	builder.WriteString(`// IRequestAuthorizer is given a chance to attach credentials to a request
+// before it is sent, and to inspect the response once it comes back
+public interface IRequestAuthorizer {
+
+	IEnumerator Authorize(UnityWebRequest req);
+
+	void Inspect(UnityWebRequest req);
+}`)
+	builder.WriteString("\n\n")
+	builder.WriteString(security.CredentialProviderClasses)
+	builder.WriteString(s.renderCredentialProviders())
+
+	written := make(map[string]bool)
+	for _, authorizer := range s.requestAuthorizers() {
+		for _, classes := range authorizer.SupportingClasses() {
+			if written[classes] {
+				continue
+			}
+			written[classes] = true
+			builder.WriteString("\n\n")
+			builder.WriteString(classes)
+		}
 	}
 	return builder.String()
 }
@@ -98,12 +307,51 @@ func (s Spec) ServiceConfig(configName, menuName string, includeScriptableObject
 	builder.WriteString(s.renderInterfaceBody())
 	builder.WriteString("}\n\n")
 
-	builder.WriteString(`public interface IWebRequest {
+	builder.WriteString(`public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
-}`)
+
+	void Abort();
+}
+
+`)
+	builder.WriteString(path.ApiResponseClasses)
+	builder.WriteString("\n\n")
+	builder.WriteString(path.RetryPolicyClass)
+	builder.WriteString("\n\n")
+	builder.WriteString(path.InterceptorClasses)
+
+	if len(s.AuthDefinitions) > 0 {
+		builder.WriteString("\n\n")
+		builder.WriteString(s.renderAuthorizerClasses())
+	}
+
+	if s.ServiceOptions.Async != path.NoAsync {
+		builder.WriteString("\n\n")
+		builder.WriteString(s.ServiceOptions.Async.RunnerClass())
+	}
+
+	// Callers and units report errors the same way callbacks do
+	if s.ServiceOptions.Callbacks || s.ServiceOptions.Callers || s.ServiceOptions.VisualScripting {
+		builder.WriteString("\n\n")
+		builder.WriteString(path.CallbackClasses)
+	}
+
+	if s.ServiceOptions.Fakes {
+		builder.WriteString("\n\n")
+		builder.WriteString(path.FakeServiceClasses)
+	}
+
+	if includeScriptableObject && s.hasSecretsOutsideAsset() {
+		builder.WriteString("\n\n")
+		builder.WriteString(s.SecretStorage.ToCSharp(configName))
+	}
 
 	// Editor Config Code
 	if includeScriptableObject {
@@ -120,23 +368,49 @@ func (s Spec) ServiceConfig(configName, menuName string, includeScriptableObject
 		fmt.Fprintf(&builder, "\t\tif (newBasePath != castedTarget.BasePath) {\n")
 		fmt.Fprintf(&builder, "\t\t\tcastedTarget.BasePath = newBasePath;\n")
 		builder.WriteString("\t\t\tUnityEditor.EditorUtility.SetDirty(target);\n\t\t}\n\n")
-		for _, authGuard := range s.AuthDefinitions {
-			propertyName := convention.TitleCase(authGuard.Identifier())
+		builder.WriteString("\t\tUnityEditor.EditorGUILayout.Space();\n")
+		fmt.Fprintf(&builder, "\t\tUnityEditor.EditorGUILayout.LabelField(\"%s\");\n", s.timeoutDescription())
+		builder.WriteString("\t\tvar newTimeout = UnityEditor.EditorGUILayout.IntField(\"Timeout\", castedTarget.Timeout);\n")
+		builder.WriteString("\t\tif (newTimeout != castedTarget.Timeout) {\n")
+		builder.WriteString("\t\t\tcastedTarget.Timeout = System.Math.Max(0, newTimeout);\n")
+		builder.WriteString("\t\t\tUnityEditor.EditorUtility.SetDirty(target);\n\t\t}\n\n")
+		builder.WriteString("\t\tUnityEditor.EditorGUILayout.Space();\n")
+		builder.WriteString("\t\tserializedObject.Update();\n")
+		builder.WriteString("\t\tUnityEditor.EditorGUILayout.PropertyField(serializedObject.FindProperty(\"retryPolicy\"), true);\n")
+		builder.WriteString("\t\tserializedObject.ApplyModifiedProperties();\n\n")
+		for _, variable := range s.configVariables() {
+			propertyName := variable.Name()
 			privateVarName := "new" + propertyName
+			field := "TextField"
+			if variable.Secret() {
+				field = "PasswordField"
+			}
 			fmt.Fprintf(&builder, "\t\tUnityEditor.EditorGUILayout.Space();\n")
-			fmt.Fprintf(&builder, "\t\tUnityEditor.EditorGUILayout.LabelField(\"%s\");\n", authGuard.String())
-			fmt.Fprintf(&builder, "\t\tvar %s = UnityEditor.EditorGUILayout.TextField(\"%s\", castedTarget.%s);\n", privateVarName, propertyName, propertyName)
+			fmt.Fprintf(&builder, "\t\tUnityEditor.EditorGUILayout.LabelField(\"%s\");\n", variable.Description())
+			if s.storesSecretOutsideAsset(variable) {
+				fmt.Fprintf(&builder, "\t\tUnityEditor.EditorGUILayout.LabelField(\"Stored in \" + %s, UnityEditor.EditorStyles.miniLabel);\n", s.SecretStorage.source(configName, variable))
+			}
+			fmt.Fprintf(&builder, "\t\tvar %s = UnityEditor.EditorGUILayout.%s(\"%s\", castedTarget.%s);\n", privateVarName, field, propertyName, propertyName)
 			fmt.Fprintf(&builder, "\t\tif (%s != castedTarget.%s) {\n", privateVarName, propertyName)
 			fmt.Fprintf(&builder, "\t\t\tcastedTarget.%s = %s;\n", propertyName, privateVarName)
-			builder.WriteString("\t\t\tUnityEditor.EditorUtility.SetDirty(target);\n\t\t}\n\n")
+			if s.storesSecretOutsideAsset(variable) {
+				// Nothing about the asset itself changed
+				builder.WriteString("\t\t}\n\n")
+			} else {
+				builder.WriteString("\t\t\tUnityEditor.EditorUtility.SetDirty(target);\n\t\t}\n\n")
+			}
 		}
 		builder.WriteString("\t}\n\n}\n#endif\n\n")
 
 		// Scriptable Object
 		builder.WriteString("[System.Serializable]\n")
 		fmt.Fprintf(&builder, "[CreateAssetMenu(menuName = \"%s\", fileName = \"%s\")]\n", menuName, properClassName)
-		fmt.Fprintf(&builder, "public class %s: ScriptableObject, Config {\n\n", properClassName)
-		builder.WriteString(s.renderScriptableObjectBody())
+		fmt.Fprintf(&builder, "public class %s: ScriptableObject, Config", properClassName)
+		for _, authGuard := range s.credentialGuards() {
+			fmt.Fprintf(&builder, ", %s", security.CredentialProvider(authGuard.Identifier()))
+		}
+		builder.WriteString(" {\n\n")
+		builder.WriteString(s.renderScriptableObjectBody(configName))
 
 		// Scriptable objects can't have constructors, need to decide on alternative method of instantiating them in code
 		// fmt.Fprintf(&builder, "\tpublic %s(string basePath) {\n", properClassName)
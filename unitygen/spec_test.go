@@ -1,9 +1,11 @@
 package unitygen_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/recolude/swagger-unity-codegen/unitygen"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
 	"github.com/recolude/swagger-unity-codegen/unitygen/security"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,14 +23,35 @@ func TestSpec_ServiceConfig_NoScriptableObject(t *testing.T) {
 	// The base URL to which the endpoint paths are appended
 	string BasePath { get; }
 
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	int Timeout { get; }
+
+	// How requests that fail are retried
+	RetryPolicy RetryPolicy { get; }
+
+	// Interceptors every request and response is run through
+	RequestInterceptors Interceptors { get; }
+
 }
 
-public interface IWebRequest {
+public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
-}`, code)
+
+	void Abort();
+}
+
+`+path.ApiResponseClasses+`
+
+`+path.RetryPolicyClass+`
+
+`+path.InterceptorClasses, code)
 }
 
 func TestSpec_ServiceConfig_NoSecurityDefinitions(t *testing.T) {
@@ -44,15 +67,36 @@ func TestSpec_ServiceConfig_NoSecurityDefinitions(t *testing.T) {
 	// The base URL to which the endpoint paths are appended
 	string BasePath { get; }
 
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	int Timeout { get; }
+
+	// How requests that fail are retried
+	RetryPolicy RetryPolicy { get; }
+
+	// Interceptors every request and response is run through
+	RequestInterceptors Interceptors { get; }
+
 }
 
-public interface IWebRequest {
+public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
+
+	void Abort();
 }
 
+`+path.ApiResponseClasses+`
+
+`+path.RetryPolicyClass+`
+
+`+path.InterceptorClasses+`
+
 #if UNITY_EDITOR
 [UnityEditor.CustomEditor(typeof(ServiceConfig))]
 public class ServiceConfigEditor : UnityEditor.Editor
@@ -75,6 +119,19 @@ public class ServiceConfigEditor : UnityEditor.Editor
 			UnityEditor.EditorUtility.SetDirty(target);
 		}
 
+		UnityEditor.EditorGUILayout.Space();
+		UnityEditor.EditorGUILayout.LabelField("Seconds a request waits for a response before giving up, 0 waits forever");
+		var newTimeout = UnityEditor.EditorGUILayout.IntField("Timeout", castedTarget.Timeout);
+		if (newTimeout != castedTarget.Timeout) {
+			castedTarget.Timeout = System.Math.Max(0, newTimeout);
+			UnityEditor.EditorUtility.SetDirty(target);
+		}
+
+		UnityEditor.EditorGUILayout.Space();
+		serializedObject.Update();
+		UnityEditor.EditorGUILayout.PropertyField(serializedObject.FindProperty("retryPolicy"), true);
+		serializedObject.ApplyModifiedProperties();
+
 	}
 
 }
@@ -90,6 +147,24 @@ public class ServiceConfig: ScriptableObject, Config {
 	// The base URL to which the endpoint paths are appended
 	public string BasePath { get { return basePath; } set { basePath = value; } }
 
+	[SerializeField]
+	private int timeout;
+
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	public int Timeout { get { return timeout; } set { timeout = value; } }
+
+	[SerializeField]
+	private RetryPolicy retryPolicy = new RetryPolicy();
+
+	// How requests that fail are retried
+	public RetryPolicy RetryPolicy { get { return retryPolicy; } set { retryPolicy = value; } }
+
+	[System.NonSerialized]
+	private RequestInterceptors interceptors;
+
+	// Interceptors every request and response is run through
+	public RequestInterceptors Interceptors { get { if (interceptors == null) { interceptors = new RequestInterceptors(); } return interceptors; } }
+
 }`, code)
 }
 
@@ -111,19 +186,75 @@ func TestSpec_ServiceConfig_MultipleSecurityDefinitions(t *testing.T) {
 	// The base URL to which the endpoint paths are appended
 	string BasePath { get; }
 
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	int Timeout { get; }
+
+	// How requests that fail are retried
+	RetryPolicy RetryPolicy { get; }
+
+	// Interceptors every request and response is run through
+	RequestInterceptors Interceptors { get; }
+
 	// AnotherIdentifier is a API Key 'DIF-KEY' found in a request's body
 	string AnotherIdentifier { get; }
 
 	// SomeIdentifier is a API Key 'DA-KEY' found in a request's header
 	string SomeIdentifier { get; }
 
+	// Where AnotherIdentifier's credentials are read from
+	IAnotherIdentifierCredentialProvider AnotherIdentifierCredentials { get; }
+
+	// Where SomeIdentifier's credentials are read from
+	ISomeIdentifierCredentialProvider SomeIdentifierCredentials { get; }
+
 }
 
-public interface IWebRequest {
+public interface IWebRequest : System.IDisposable {
 
 	UnityWebRequest UnderlyingRequest{ get; }
 
+	IApiResponse Response { get; }
+
+	bool DisposeOnComplete { get; set; }
+
 	IEnumerator Run();
+
+	void Abort();
+}
+
+`+path.ApiResponseClasses+`
+
+`+path.RetryPolicyClass+`
+
+`+path.InterceptorClasses+`
+
+// IRequestAuthorizer is given a chance to attach credentials to a request
+// before it is sent, and to inspect the response once it comes back
+public interface IRequestAuthorizer {
+
+	IEnumerator Authorize(UnityWebRequest req);
+
+	void Inspect(UnityWebRequest req);
+}
+
+`+security.CredentialProviderClasses+`
+
+// IAnotherIdentifierCredentialProvider supplies AnotherIdentifier's credentials at runtime.
+// Implement IAsyncCredentialProvider too if they have to be fetched first.
+public interface IAnotherIdentifierCredentialProvider {
+
+	// AnotherIdentifier is a API Key 'DIF-KEY' found in a request's body
+	string AnotherIdentifier { get; }
+
+}
+
+// ISomeIdentifierCredentialProvider supplies SomeIdentifier's credentials at runtime.
+// Implement IAsyncCredentialProvider too if they have to be fetched first.
+public interface ISomeIdentifierCredentialProvider {
+
+	// SomeIdentifier is a API Key 'DA-KEY' found in a request's header
+	string SomeIdentifier { get; }
+
 }
 
 #if UNITY_EDITOR
@@ -148,9 +279,22 @@ public class RecoludeConfigEditor : UnityEditor.Editor
 			UnityEditor.EditorUtility.SetDirty(target);
 		}
 
+		UnityEditor.EditorGUILayout.Space();
+		UnityEditor.EditorGUILayout.LabelField("Seconds a request waits for a response before giving up, 0 waits forever");
+		var newTimeout = UnityEditor.EditorGUILayout.IntField("Timeout", castedTarget.Timeout);
+		if (newTimeout != castedTarget.Timeout) {
+			castedTarget.Timeout = System.Math.Max(0, newTimeout);
+			UnityEditor.EditorUtility.SetDirty(target);
+		}
+
+		UnityEditor.EditorGUILayout.Space();
+		serializedObject.Update();
+		UnityEditor.EditorGUILayout.PropertyField(serializedObject.FindProperty("retryPolicy"), true);
+		serializedObject.ApplyModifiedProperties();
+
 		UnityEditor.EditorGUILayout.Space();
 		UnityEditor.EditorGUILayout.LabelField("AnotherIdentifier is a API Key 'DIF-KEY' found in a request's body");
-		var newAnotherIdentifier = UnityEditor.EditorGUILayout.TextField("AnotherIdentifier", castedTarget.AnotherIdentifier);
+		var newAnotherIdentifier = UnityEditor.EditorGUILayout.PasswordField("AnotherIdentifier", castedTarget.AnotherIdentifier);
 		if (newAnotherIdentifier != castedTarget.AnotherIdentifier) {
 			castedTarget.AnotherIdentifier = newAnotherIdentifier;
 			UnityEditor.EditorUtility.SetDirty(target);
@@ -158,7 +302,7 @@ public class RecoludeConfigEditor : UnityEditor.Editor
 
 		UnityEditor.EditorGUILayout.Space();
 		UnityEditor.EditorGUILayout.LabelField("SomeIdentifier is a API Key 'DA-KEY' found in a request's header");
-		var newSomeIdentifier = UnityEditor.EditorGUILayout.TextField("SomeIdentifier", castedTarget.SomeIdentifier);
+		var newSomeIdentifier = UnityEditor.EditorGUILayout.PasswordField("SomeIdentifier", castedTarget.SomeIdentifier);
 		if (newSomeIdentifier != castedTarget.SomeIdentifier) {
 			castedTarget.SomeIdentifier = newSomeIdentifier;
 			UnityEditor.EditorUtility.SetDirty(target);
@@ -171,7 +315,7 @@ public class RecoludeConfigEditor : UnityEditor.Editor
 
 [System.Serializable]
 [CreateAssetMenu(menuName = "Recolude/Config", fileName = "RecoludeConfig")]
-public class RecoludeConfig: ScriptableObject, Config {
+public class RecoludeConfig: ScriptableObject, Config, IAnotherIdentifierCredentialProvider, ISomeIdentifierCredentialProvider {
 
 	[SerializeField]
 	private string basePath;
@@ -179,6 +323,24 @@ public class RecoludeConfig: ScriptableObject, Config {
 	// The base URL to which the endpoint paths are appended
 	public string BasePath { get { return basePath; } set { basePath = value; } }
 
+	[SerializeField]
+	private int timeout;
+
+	// Seconds a request waits for a response before giving up, 0 waits forever
+	public int Timeout { get { return timeout; } set { timeout = value; } }
+
+	[SerializeField]
+	private RetryPolicy retryPolicy = new RetryPolicy();
+
+	// How requests that fail are retried
+	public RetryPolicy RetryPolicy { get { return retryPolicy; } set { retryPolicy = value; } }
+
+	[System.NonSerialized]
+	private RequestInterceptors interceptors;
+
+	// Interceptors every request and response is run through
+	public RequestInterceptors Interceptors { get { if (interceptors == null) { interceptors = new RequestInterceptors(); } return interceptors; } }
+
 	[SerializeField]
 	private string anotherIdentifier;
 
@@ -191,5 +353,226 @@ public class RecoludeConfig: ScriptableObject, Config {
 	// SomeIdentifier is a API Key 'DA-KEY' found in a request's header
 	public string SomeIdentifier { get { return someIdentifier; } set { someIdentifier = value; } }
 
+	[System.NonSerialized]
+	private IAnotherIdentifierCredentialProvider anotherIdentifierCredentials;
+
+	// Where AnotherIdentifier's credentials are read from, the values set on this config unless replaced
+	public IAnotherIdentifierCredentialProvider AnotherIdentifierCredentials { get { return anotherIdentifierCredentials ?? this; } set { anotherIdentifierCredentials = value; } }
+
+	[System.NonSerialized]
+	private ISomeIdentifierCredentialProvider someIdentifierCredentials;
+
+	// Where SomeIdentifier's credentials are read from, the values set on this config unless replaced
+	public ISomeIdentifierCredentialProvider SomeIdentifierCredentials { get { return someIdentifierCredentials ?? this; } set { someIdentifierCredentials = value; } }
+
 }`, code)
 }
+
+func TestSpec_ServiceConfig_OAuth2(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		AuthDefinitions: []security.Auth{
+			security.NewOAuth2("PlayerAuth", []security.OAuth2Flow{
+				security.NewOAuth2Flow(security.ClientCredentialsFlow, "", "https://example.com/token", "", nil),
+			}),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `	// Client ID used when requesting PlayerAuth tokens
+	string PlayerAuthClientId { get; }
+
+	// Client secret used when requesting PlayerAuth tokens
+	string PlayerAuthClientSecret { get; }
+
+	// Where PlayerAuth's credentials are read from
+	IPlayerAuthCredentialProvider PlayerAuthCredentials { get; }
+
+	// PlayerAuth is OAuth2 using the clientCredentials flow
+	OAuthTokenStore PlayerAuthTokens { get; }
+`)
+	assert.Contains(t, code, "public class OAuthTokenStore : IRequestAuthorizer {")
+	assert.Contains(t, code, `var newPlayerAuthClientSecret = UnityEditor.EditorGUILayout.PasswordField("PlayerAuthClientSecret", castedTarget.PlayerAuthClientSecret);`)
+	assert.Contains(t, code, `	[System.NonSerialized]
+	private OAuthTokenStore playerAuthTokens;
+
+	// PlayerAuth is OAuth2 using the clientCredentials flow
+	public OAuthTokenStore PlayerAuthTokens { get { if (playerAuthTokens == null) { playerAuthTokens = new OAuthTokenStore("https://example.com/token", "", "", "client_credentials", () => this.PlayerAuthCredentials.PlayerAuthClientId, () => this.PlayerAuthCredentials.PlayerAuthClientSecret); } return playerAuthTokens; } }
+`)
+}
+
+func TestSpec_ServiceConfig_MasksBasicAuthPassword(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		AuthDefinitions: []security.Auth{
+			security.NewBasicAuth("AdminAuth"),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `var newAdminAuthUsername = UnityEditor.EditorGUILayout.TextField("AdminAuthUsername", castedTarget.AdminAuthUsername);`)
+	assert.Contains(t, code, `var newAdminAuthPassword = UnityEditor.EditorGUILayout.PasswordField("AdminAuthPassword", castedTarget.AdminAuthPassword);`)
+}
+
+func TestSpec_ServiceConfig_CredentialProviderPerSecurityDefinition(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		AuthDefinitions: []security.Auth{
+			security.NewBasicAuth("AdminAuth"),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `public interface IAdminAuthCredentialProvider {
+
+	// Username sent with AdminAuth
+	string AdminAuthUsername { get; }
+
+	// Password sent with AdminAuth
+	string AdminAuthPassword { get; }
+
+}`)
+	assert.Contains(t, code, "public interface IAsyncCredentialProvider {")
+	assert.Contains(t, code, "public class RecoludeConfig: ScriptableObject, Config, IAdminAuthCredentialProvider {")
+	assert.Contains(t, code, "public IAdminAuthCredentialProvider AdminAuthCredentials { get { return adminAuthCredentials ?? this; } set { adminAuthCredentials = value; } }")
+}
+
+func TestSpec_ServiceConfig_SecretsKeptOutOfAsset(t *testing.T) {
+	tests := map[string]struct {
+		storage unitygen.SecretStorage
+		key     string
+		class   string
+	}{
+		"prefs":            {storage: unitygen.PrefsSecretStorage, key: "RecoludeConfig.AdminAuthPassword", class: "keeps RecoludeConfig's secrets in EditorPrefs"},
+		"environment":      {storage: unitygen.EnvironmentSecretStorage, key: "ADMIN_AUTH_PASSWORD", class: "reads RecoludeConfig's secrets from command line arguments"},
+		"streaming assets": {storage: unitygen.StreamingAssetsSecretStorage, key: "AdminAuthPassword", class: `public const string FileName = "RecoludeConfig.secrets.json";`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// ******************************** ARRANGE *******************************
+			service := unitygen.Spec{
+				AuthDefinitions: []security.Auth{
+					security.NewBasicAuth("AdminAuth"),
+				},
+				SecretStorage: tc.storage,
+			}
+
+			// ********************************** ACT *********************************
+			code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+			// ********************************* ASSERT *******************************
+			assert.Contains(t, code, tc.class)
+			assert.Contains(t, code, "public static class RecoludeConfigSecrets {")
+			assert.Contains(t, code, `public string AdminAuthPassword { get { return RecoludeConfigSecrets.Read("`+tc.key+`"); } set { RecoludeConfigSecrets.Write("`+tc.key+`", value); } }`)
+			assert.NotContains(t, code, "private string adminAuthPassword;")
+			assert.Contains(t, code, `UnityEditor.EditorGUILayout.LabelField("Stored in " + RecoludeConfigSecrets.Source("`+tc.key+`"), UnityEditor.EditorStyles.miniLabel);
+		var newAdminAuthPassword = UnityEditor.EditorGUILayout.PasswordField("AdminAuthPassword", castedTarget.AdminAuthPassword);
+		if (newAdminAuthPassword != castedTarget.AdminAuthPassword) {
+			castedTarget.AdminAuthPassword = newAdminAuthPassword;
+		}`)
+
+			// Usernames aren't secret, and stay in the asset
+			assert.Contains(t, code, "private string adminAuthUsername;")
+		})
+	}
+}
+
+func TestSpec_ServiceConfig_SecretsInAssetByDefault(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		AuthDefinitions: []security.Auth{
+			security.NewBasicAuth("AdminAuth"),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, code, "RecoludeConfigSecrets")
+	assert.Contains(t, code, "private string adminAuthPassword;")
+}
+
+func TestSpec_ServiceConfig_OpenIDConnectReusesOAuth2Classes(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		AuthDefinitions: []security.Auth{
+			security.NewOAuth2("PetAuth", []security.OAuth2Flow{
+				security.NewOAuth2Flow(security.AuthorizationCodeFlow, "https://example.com/authorize", "https://example.com/token", "", nil),
+			}),
+			security.NewOpenIDConnect("PlayerAuth", "https://example.com/.well-known/openid-configuration", nil),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", true)
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, 1, strings.Count(code, "public class OAuthTokenStore : IRequestAuthorizer {"))
+	assert.Equal(t, 1, strings.Count(code, "public class OAuthPKCELogin {"))
+	assert.Equal(t, 1, strings.Count(code, "public class OpenIDConnect : IRequestAuthorizer {"))
+	assert.Contains(t, code, "\tOpenIDConnect PlayerAuthConnect { get; }\n")
+}
+
+func TestSpec_ServiceConfig_AsyncRunner(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		ServiceOptions: unitygen.ServiceOptions{Async: path.AwaitableAsync},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "public static class WebRequestTasks {")
+	assert.Contains(t, code, "public static async Awaitable<T> RunAsync<T>(this T request")
+}
+
+func TestSpec_ServiceConfig_CallbackRunner(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		ServiceOptions: unitygen.ServiceOptions{Callbacks: true},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "public class ApiError {")
+	assert.Contains(t, code, "public class WebRequestRunner : MonoBehaviour {")
+}
+
+func TestSpec_ServiceConfig_FakeServiceBase(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{
+		ServiceOptions: unitygen.ServiceOptions{Fakes: true},
+	}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", false)
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, "\n\n"+path.FakeServiceClasses)
+}
+
+func TestSpec_ServiceConfig_NoCallbackRunnerByDefault(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	service := unitygen.Spec{}
+
+	// ********************************** ACT *********************************
+	code := service.ServiceConfig("RecoludeConfig", "Recolude/Config", false)
+
+	// ********************************* ASSERT *******************************
+	assert.NotContains(t, code, "WebRequestRunner")
+	assert.NotContains(t, code, "FakeService")
+}
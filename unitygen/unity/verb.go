@@ -1,12 +1,14 @@
 package unity
 
 import (
-	"fmt"
 	"net/http"
+	"strconv"
 )
 
 // ToUnityHTTPVerb takes how golang represents a HTTP verb (http.MethodGet) and
-// translates it for unity (UnityWebRequest.kHttpVerbGET).
+// translates it for unity (UnityWebRequest.kHttpVerbGET). Verbs unity does
+// not have a constant for are written out as string literals, since
+// UnityWebRequest accepts any method.
 func ToUnityHTTPVerb(httpMethod string) string {
 	switch httpMethod {
 	case http.MethodGet:
@@ -20,5 +22,5 @@ func ToUnityHTTPVerb(httpMethod string) string {
 	case http.MethodHead:
 		return "UnityWebRequest.kHttpVerbHEAD"
 	}
-	panic(fmt.Sprintf("unknown verb \"%s\"", httpMethod))
+	return strconv.Quote(httpMethod)
 }
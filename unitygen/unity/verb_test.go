@@ -13,11 +13,15 @@ func TestToUnityHTTPVerb(t *testing.T) {
 		input string
 		want  string
 	}{
-		"get":    {input: http.MethodGet, want: "UnityWebRequest.kHttpVerbGET"},
-		"put":    {input: http.MethodPut, want: "UnityWebRequest.kHttpVerbPUT"},
-		"post":   {input: http.MethodPost, want: "UnityWebRequest.kHttpVerbPOST"},
-		"delete": {input: http.MethodDelete, want: "UnityWebRequest.kHttpVerbDELETE"},
-		"head":   {input: http.MethodHead, want: "UnityWebRequest.kHttpVerbHEAD"},
+		"get":     {input: http.MethodGet, want: "UnityWebRequest.kHttpVerbGET"},
+		"put":     {input: http.MethodPut, want: "UnityWebRequest.kHttpVerbPUT"},
+		"post":    {input: http.MethodPost, want: "UnityWebRequest.kHttpVerbPOST"},
+		"delete":  {input: http.MethodDelete, want: "UnityWebRequest.kHttpVerbDELETE"},
+		"head":    {input: http.MethodHead, want: "UnityWebRequest.kHttpVerbHEAD"},
+		"patch":   {input: http.MethodPatch, want: `"PATCH"`},
+		"options": {input: http.MethodOptions, want: `"OPTIONS"`},
+		"trace":   {input: http.MethodTrace, want: `"TRACE"`},
+		"custom":  {input: "PURGE", want: `"PURGE"`},
 	}
 
 	for name, tc := range tests {
@@ -27,13 +31,3 @@ func TestToUnityHTTPVerb(t *testing.T) {
 		})
 	}
 }
-
-func TestPanicAtUnkown(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("The code did not panic")
-		}
-	}()
-
-	unity.ToUnityHTTPVerb("unknown")
-}
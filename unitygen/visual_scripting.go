@@ -0,0 +1,71 @@
+package unitygen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen/convention"
+)
+
+// unitCategory is where the service's units are found in the fuzzy finder
+func (s Spec) unitCategory(service Service) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(s.ClientName(), "Client"), service.className())
+}
+
+// VisualScriptingUnits prints out a Visual Scripting unit for every request
+// every service can make, sent with the scriptable object config with the
+// name given
+func (s Spec) VisualScriptingUnits(configName string) string {
+	units := make([]string, 0)
+	for _, service := range s.Services {
+		for _, p := range service.Paths() {
+			units = append(units, p.VisualScriptingUnit(service.className(), configName, s.unitCategory(service)))
+		}
+	}
+	return strings.Join(units, "\n\n")
+}
+
+// VisualScriptingTypeOptions prints out an editor script that adds the
+// definitions and the config to Visual Scripting's type options, rebuilding
+// the node library whenever one is missing so the generated units show up in
+// the fuzzy finder. It uses editor only assemblies, so it has to be written
+// to an Editor folder.
+func (s Spec) VisualScriptingTypeOptions(configName string) string {
+	builder := strings.Builder{}
+	builder.WriteString("// VisualScriptingTypeOptions makes the generated types available to Visual\n")
+	builder.WriteString("// Scripting graphs every time scripts are reloaded\n")
+	builder.WriteString("[UnityEditor.InitializeOnLoad]\n")
+	builder.WriteString("public static class VisualScriptingTypeOptions {\n\n")
+
+	builder.WriteString("\tprivate static readonly System.Type[] types = new System.Type[] {\n")
+	fmt.Fprintf(&builder, "\t\ttypeof(%s),\n", convention.TitleCase(configName))
+	for _, def := range s.Definitions {
+		fmt.Fprintf(&builder, "\t\ttypeof(%s),\n", def.ToVariableType())
+	}
+	builder.WriteString("\t};\n\n")
+
+	builder.WriteString("\tstatic VisualScriptingTypeOptions() {\n")
+	builder.WriteString("\t\t// Visual Scripting isn't ready until the editor has finished loading\n")
+	builder.WriteString("\t\tUnityEditor.EditorApplication.delayCall += Register;\n")
+	builder.WriteString("\t}\n\n")
+
+	builder.WriteString("\t// Register adds whichever generated types are missing from the type\n")
+	builder.WriteString("\t// options, and regenerates the node library if any were\n")
+	fmt.Fprintf(&builder, "\t[UnityEditor.MenuItem(\"Tools/%s/Register Visual Scripting Types\")]\n", s.ClientName())
+	builder.WriteString("\tpublic static void Register() {\n")
+	builder.WriteString("\t\tvar typeOptions = Unity.VisualScripting.BoltCore.Configuration.typeOptions;\n")
+	builder.WriteString("\t\tvar added = false;\n")
+	builder.WriteString("\t\tforeach (var type in types) {\n")
+	builder.WriteString("\t\t\tif (typeOptions.Contains(type) == false) {\n")
+	builder.WriteString("\t\t\t\ttypeOptions.Add(type);\n")
+	builder.WriteString("\t\t\t\tadded = true;\n")
+	builder.WriteString("\t\t\t}\n")
+	builder.WriteString("\t\t}\n\n")
+	builder.WriteString("\t\tif (added) {\n")
+	builder.WriteString("\t\t\tUnity.VisualScripting.BoltCore.Configuration.Save();\n")
+	builder.WriteString("\t\t\tUnity.VisualScripting.UnitBase.Rebuild();\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("}")
+	return builder.String()
+}
@@ -0,0 +1,89 @@
+package unitygen_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/recolude/swagger-unity-codegen/unitygen"
+	"github.com/recolude/swagger-unity-codegen/unitygen/model"
+	"github.com/recolude/swagger-unity-codegen/unitygen/path"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec_VisualScriptingUnits_CategorizedByService(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	spec := unitygen.Spec{
+		Info: unitygen.SpecInfo{Title: "Recolude"},
+		Services: []unitygen.Service{
+			unitygen.NewService("recording", []path.Path{
+				path.NewPath("/recordings", "ListRecordings", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+			}),
+			unitygen.NewService("user", []path.Path{
+				path.NewPath("/users/me", "GetMe", http.MethodGet, nil, nil, nil, nil, path.PathOptions{}),
+			}),
+		},
+	}
+
+	// ********************************** ACT *********************************
+	code := spec.VisualScriptingUnits("RecoludeConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Contains(t, code, `[Unity.VisualScripting.UnitCategory("Recolude/RecordingService")]
+public class ListRecordingsUnit : Unity.VisualScripting.Unit {`)
+	assert.Contains(t, code, `[Unity.VisualScripting.UnitCategory("Recolude/UserService")]
+public class GetMeUnit : Unity.VisualScripting.Unit {`)
+	assert.Contains(t, code, "var req = new RecordingService(serviceConfig).ListRecordings();")
+}
+
+func TestSpec_VisualScriptingTypeOptions(t *testing.T) {
+	// ******************************** ARRANGE *******************************
+	spec := unitygen.NewSpec(
+		unitygen.SpecInfo{Title: "Recolude"},
+		[]model.Definition{
+			model.NewObject("recording", nil),
+			model.NewStringEnum("visibility", []string{"public", "private"}),
+		},
+		nil,
+		nil,
+	)
+
+	// ********************************** ACT *********************************
+	code := spec.VisualScriptingTypeOptions("RecoludeConfig")
+
+	// ********************************* ASSERT *******************************
+	assert.Equal(t, `// VisualScriptingTypeOptions makes the generated types available to Visual
+// Scripting graphs every time scripts are reloaded
+[UnityEditor.InitializeOnLoad]
+public static class VisualScriptingTypeOptions {
+
+	private static readonly System.Type[] types = new System.Type[] {
+		typeof(RecoludeConfig),
+		typeof(Recording),
+		typeof(Visibility),
+	};
+
+	static VisualScriptingTypeOptions() {
+		// Visual Scripting isn't ready until the editor has finished loading
+		UnityEditor.EditorApplication.delayCall += Register;
+	}
+
+	// Register adds whichever generated types are missing from the type
+	// options, and regenerates the node library if any were
+	[UnityEditor.MenuItem("Tools/RecoludeClient/Register Visual Scripting Types")]
+	public static void Register() {
+		var typeOptions = Unity.VisualScripting.BoltCore.Configuration.typeOptions;
+		var added = false;
+		foreach (var type in types) {
+			if (typeOptions.Contains(type) == false) {
+				typeOptions.Add(type);
+				added = true;
+			}
+		}
+
+		if (added) {
+			Unity.VisualScripting.BoltCore.Configuration.Save();
+			Unity.VisualScripting.UnitBase.Rebuild();
+		}
+	}
+}`, code)
+}